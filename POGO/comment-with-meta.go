@@ -0,0 +1,10 @@
+package POGO
+
+// A JIRA issue comment along with the Mavenlink post it originates from
+type CommentWithMeta struct {
+	Id                   string
+	Body                 string
+	MavenlinkPostId      string
+	MavenlinkTaskId      string
+	MavenlinkPostUpdated string
+}
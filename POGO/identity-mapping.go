@@ -0,0 +1,91 @@
+package POGO
+
+import (
+	jira "github.com/desertjinn/jira-communicator/proto/jira-communicator"
+	"strings"
+)
+
+type IdentityMappingInterface interface {
+	AddUser(mavenlinkUserId string, jiraUser jira.Author)
+	GetUser(mavenlinkUserId string) *jira.Author
+	GetUsers() map[string]*jira.Author
+	AddDomainAlias(mavenlinkDomain string, jiraDomain string)
+	GetDomainAlias(mavenlinkDomain string) string
+	SetFallbackAuthor(jiraUser jira.Author)
+	GetFallbackAuthor() *jira.Author
+	SetSyncAccount(jiraAccount string)
+	IsSyncAuthor(author *jira.Author) bool
+}
+
+// Explicit Mavenlink user → JIRA user links, Mavenlink → JIRA email domain aliases, the project's fallback author &
+// the JIRA account the sync acts as
+type IdentityMapping struct {
+	users          map[string]*jira.Author
+	domainAliases  map[string]string
+	fallbackAuthor *jira.Author
+	syncAccount    string
+}
+
+func (im *IdentityMapping) AddUser(mavenlinkUserId string, jiraUser jira.Author) {
+	if im.users == nil {
+		im.users = map[string]*jira.Author{}
+	}
+	im.users[mavenlinkUserId] = &jiraUser
+}
+func (im *IdentityMapping) GetUser(mavenlinkUserId string) *jira.Author {
+	if im == nil || im.users == nil {
+		return nil
+	}
+	return im.users[mavenlinkUserId]
+}
+func (im *IdentityMapping) GetUsers() map[string]*jira.Author {
+	if im == nil {
+		return nil
+	}
+	return im.users
+}
+func (im *IdentityMapping) AddDomainAlias(mavenlinkDomain string, jiraDomain string) {
+	if im.domainAliases == nil {
+		im.domainAliases = map[string]string{}
+	}
+	im.domainAliases[strings.ToLower(mavenlinkDomain)] = strings.ToLower(jiraDomain)
+}
+func (im *IdentityMapping) GetDomainAlias(mavenlinkDomain string) string {
+	if im == nil || im.domainAliases == nil {
+		return ""
+	}
+	return im.domainAliases[strings.ToLower(mavenlinkDomain)]
+}
+func (im *IdentityMapping) SetFallbackAuthor(jiraUser jira.Author) {
+	im.fallbackAuthor = &jiraUser
+}
+func (im *IdentityMapping) GetFallbackAuthor() *jira.Author {
+	if im == nil {
+		return nil
+	}
+	return im.fallbackAuthor
+}
+func (im *IdentityMapping) SetSyncAccount(jiraAccount string) {
+	im.syncAccount = jiraAccount
+}
+
+// Check if a JIRA author is the account the sync acts as or the project's fallback author, whose worklogs & changes
+// are the sync's own
+func (im *IdentityMapping) IsSyncAuthor(author *jira.Author) bool {
+	if im == nil || author == nil {
+		return false
+	}
+	for _, account := range []string{author.AccountId, author.Name, author.EmailAddress} {
+		if len(account) == 0 {
+			continue
+		}
+		if len(im.syncAccount) > 0 && strings.EqualFold(account, im.syncAccount) {
+			return true
+		}
+		if im.fallbackAuthor != nil && (strings.EqualFold(account, im.fallbackAuthor.AccountId) ||
+			strings.EqualFold(account, im.fallbackAuthor.Name)) {
+			return true
+		}
+	}
+	return false
+}
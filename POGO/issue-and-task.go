@@ -6,32 +6,73 @@ import (
 )
 
 type IssueAndTaskInterface interface {
+	SetExternalProjectId(externalProjectId int32)
+	GetExternalProjectId() int32
 	SetProject(project jira.Project)
 	GetProject() *jira.Project
 	SetEpic(epic jira.Issue)
 	GetEpic() *jira.Issue
 	SetUsers(users []jira.Author)
 	GetUsers() *jira.Project
+	SetMavenlinkUsers(users []mavenlink.User)
+	GetMavenlinkUsers() []*mavenlink.User
 	SetTasks(tasks []mavenlink.Task)
 	GetTasks() []*mavenlink.Task
 	SetTimeentries(timeentries []mavenlink.Timeentry)
 	GetTimeentries() []*mavenlink.Timeentry
 	AddTimeentry(timeentry mavenlink.Timeentry)
+	AddSubTaskFailure(milestoneId string)
+	GetSubTaskFailures() []string
+	AddTaskFailure(subTaskId string)
+	GetTaskFailures() []string
+	AddTimeentryFailure(taskId string)
+	GetTimeentryFailures() []string
 	SetIssues(issues []jira.Issue)
 	GetIssues() []*jira.Issue
 	SetWorklogs(worklogs []jira.Worklog)
 	GetWorklogs() []*jira.Worklog
 	AddWorklog(worklog jira.Worklog)
+	GetPosts() []*mavenlink.Post
+	AddPost(post mavenlink.Post)
+	GetAttachments() []*mavenlink.Attachment
+	AddAttachment(attachment mavenlink.Attachment)
+	SetIdentityMapping(identities *IdentityMapping)
+	GetIdentityMapping() *IdentityMapping
+	SetTimezone(timezone string)
+	GetTimezone() string
+	SetEpicMode(epicMode bool)
+	IsEpicMode() bool
 }
 
 type IssueAndTask struct {
-	project     *jira.Project
-	epic        *jira.Issue
-	users       []*jira.Author
-	tasks       []*mavenlink.Task
-	timeentries []*mavenlink.Timeentry
-	issues      []*jira.Issue
-	worklogs    []*jira.Worklog
+	externalProjectId int32
+	project           *jira.Project
+	epic              *jira.Issue
+	users             []*jira.Author
+	mlUsers           []*mavenlink.User
+	tasks             []*mavenlink.Task
+	timeentries       []*mavenlink.Timeentry
+	// Milestones whose sub-tasks couldn't be retrieved
+	subTaskFailures []string
+	// Sub-tasks whose tasks couldn't be retrieved
+	taskFailures []string
+	// Tasks whose time entries couldn't be retrieved
+	timeentryFailures []string
+	issues            []*jira.Issue
+	worklogs          []*jira.Worklog
+	posts             []*mavenlink.Post
+	attachments       []*mavenlink.Attachment
+	identities        *IdentityMapping
+	timezone          string
+	epicMode          bool
+}
+
+func (st *IssueAndTask) SetExternalProjectId(externalProjectId int32) {
+	st.externalProjectId = externalProjectId
+}
+
+func (st *IssueAndTask) GetExternalProjectId() int32 {
+	return st.externalProjectId
 }
 
 func (st *IssueAndTask) SetProject(project *jira.Project) {
@@ -60,6 +101,16 @@ func (st *IssueAndTask) GetUsers() []*jira.Author {
 	return st.users
 }
 
+func (st *IssueAndTask) SetMavenlinkUsers(users []mavenlink.User) {
+	for userKey := range users {
+		st.mlUsers = append(st.mlUsers, &users[userKey])
+	}
+}
+
+func (st *IssueAndTask) GetMavenlinkUsers() []*mavenlink.User {
+	return st.mlUsers
+}
+
 func (st *IssueAndTask) GetTasks() []*mavenlink.Task {
 	return st.tasks
 }
@@ -79,6 +130,24 @@ func (st *IssueAndTask) SetTimeentries(timeentries []mavenlink.Timeentry) {
 func (st *IssueAndTask) AddTimeentry(timeentry mavenlink.Timeentry) {
 	st.timeentries = append(st.timeentries, &timeentry)
 }
+func (st *IssueAndTask) AddSubTaskFailure(milestoneId string) {
+	st.subTaskFailures = append(st.subTaskFailures, milestoneId)
+}
+func (st *IssueAndTask) GetSubTaskFailures() []string {
+	return st.subTaskFailures
+}
+func (st *IssueAndTask) AddTaskFailure(subTaskId string) {
+	st.taskFailures = append(st.taskFailures, subTaskId)
+}
+func (st *IssueAndTask) GetTaskFailures() []string {
+	return st.taskFailures
+}
+func (st *IssueAndTask) AddTimeentryFailure(taskId string) {
+	st.timeentryFailures = append(st.timeentryFailures, taskId)
+}
+func (st *IssueAndTask) GetTimeentryFailures() []string {
+	return st.timeentryFailures
+}
 func (st *IssueAndTask) GetIssues() []*jira.Issue {
 	return st.issues
 }
@@ -98,3 +167,33 @@ func (st *IssueAndTask) SetWorklogs(worklogs []jira.Worklog) {
 func (st *IssueAndTask) AddWorklog(worklog jira.Worklog) {
 	st.worklogs = append(st.worklogs, &worklog)
 }
+func (st *IssueAndTask) GetPosts() []*mavenlink.Post {
+	return st.posts
+}
+func (st *IssueAndTask) AddPost(post mavenlink.Post) {
+	st.posts = append(st.posts, &post)
+}
+func (st *IssueAndTask) GetAttachments() []*mavenlink.Attachment {
+	return st.attachments
+}
+func (st *IssueAndTask) AddAttachment(attachment mavenlink.Attachment) {
+	st.attachments = append(st.attachments, &attachment)
+}
+func (st *IssueAndTask) SetIdentityMapping(identities *IdentityMapping) {
+	st.identities = identities
+}
+func (st *IssueAndTask) GetIdentityMapping() *IdentityMapping {
+	return st.identities
+}
+func (st *IssueAndTask) SetTimezone(timezone string) {
+	st.timezone = timezone
+}
+func (st *IssueAndTask) GetTimezone() string {
+	return st.timezone
+}
+func (st *IssueAndTask) SetEpicMode(epicMode bool) {
+	st.epicMode = epicMode
+}
+func (st *IssueAndTask) IsEpicMode() bool {
+	return st.epicMode
+}
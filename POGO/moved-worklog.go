@@ -0,0 +1,13 @@
+package POGO
+
+import (
+	jira "github.com/desertjinn/jira-communicator/proto/jira-communicator"
+)
+
+// A JIRA worklog whose Mavenlink time entry has been moved to a task linked to another issue, or that was logged by
+// the fallback author & can now be reassigned to its JIRA user
+type MovedWorklog struct {
+	Worklog         jira.WorklogWithMeta
+	PreviousIssueId string
+	Reassigned      bool
+}
@@ -6,25 +6,40 @@ import (
 )
 
 type SprintAndTaskInterface interface {
+	SetExternalProjectId(externalProjectId int32)
+	GetExternalProjectId() int32
 	SetTasks(tasks []mavenlink.Task)
 	GetTasks() []*mavenlink.Task
 	SetSubTasks(tasks []mavenlink.Task)
 	GetSubTasks() []*mavenlink.Task
 	SetRapidViews(sprints []jira.GreenhopperRapidView)
 	GetRapidViews() []*jira.GreenhopperRapidView
+	SetRapidView(rapidView *jira.GreenhopperRapidView)
+	GetRapidView() *jira.GreenhopperRapidView
 	SetSprints(sprints []jira.Sprint)
 	GetSprints() []*jira.Sprint
+	SetEpics(epics []jira.Issue)
+	GetEpics() []*jira.Issue
 	HasValidSprintsAndTasks() bool
 	GetTasksToBeProcessed(toBeCreated bool) ([]*mavenlink.Task, map[string]*jira.Sprint)
 }
 
 type SprintAndTask struct {
-	tasks      []*mavenlink.Task
-	subTasks   []*mavenlink.Task
-	rapidViews []*jira.GreenhopperRapidView
-	sprints    []*jira.Sprint
+	externalProjectId int32
+	tasks             []*mavenlink.Task
+	subTasks          []*mavenlink.Task
+	rapidViews        []*jira.GreenhopperRapidView
+	rapidView         *jira.GreenhopperRapidView
+	sprints           []*jira.Sprint
+	epics             []*jira.Issue
 }
 
+func (st *SprintAndTask) SetExternalProjectId(externalProjectId int32) {
+	st.externalProjectId = externalProjectId
+}
+func (st *SprintAndTask) GetExternalProjectId() int32 {
+	return st.externalProjectId
+}
 func (st *SprintAndTask) GetTasks() []*mavenlink.Task {
 	return st.tasks
 }
@@ -49,6 +64,12 @@ func (st *SprintAndTask) SetRapidViews(rapidViews []jira.GreenhopperRapidView) {
 		st.rapidViews = append(st.rapidViews, &rapidViews[rapidViewKey])
 	}
 }
+func (st *SprintAndTask) GetRapidView() *jira.GreenhopperRapidView {
+	return st.rapidView
+}
+func (st *SprintAndTask) SetRapidView(rapidView *jira.GreenhopperRapidView) {
+	st.rapidView = rapidView
+}
 func (st *SprintAndTask) GetSprints() []*jira.Sprint {
 	return st.sprints
 }
@@ -57,6 +78,14 @@ func (st *SprintAndTask) SetSprints(sprints []jira.Sprint) {
 		st.sprints = append(st.sprints, &sprints[sprintKey])
 	}
 }
+func (st *SprintAndTask) GetEpics() []*jira.Issue {
+	return st.epics
+}
+func (st *SprintAndTask) SetEpics(epics []jira.Issue) {
+	for epicKey := range epics {
+		st.epics = append(st.epics, &epics[epicKey])
+	}
+}
 
 func (st *SprintAndTask) HasValidSprintsAndTasks() bool {
 	var has bool
@@ -0,0 +1,11 @@
+package POGO
+
+import (
+	mavenlink "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+)
+
+// The sub-tasks of Mavenlink milestones, along with the milestones whose sub-tasks couldn't be retrieved
+type SubTasksInMilestones struct {
+	SubTasks           []mavenlink.Task
+	FailedMilestoneIds []string
+}
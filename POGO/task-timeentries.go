@@ -0,0 +1,12 @@
+package POGO
+
+import (
+	mavenlink "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+)
+
+// The time entries of a Mavenlink task, or the failure to retrieve them, so the task isn't taken for one without any
+type TaskTimeentries struct {
+	TaskId      string
+	Timeentries []mavenlink.Timeentry
+	Err         error
+}
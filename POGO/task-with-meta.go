@@ -0,0 +1,15 @@
+package POGO
+
+import (
+	mavenlink "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+)
+
+// A Mavenlink task along with the changes detected on its linked JIRA issue
+type TaskWithMeta struct {
+	Task             *mavenlink.Task
+	ExistingIssueKey string
+	State            string
+	Assignee         *mavenlink.User
+	StateChanged     bool
+	AssigneeChanged  bool
+}
@@ -0,0 +1,11 @@
+package POGO
+
+import (
+	mavenlink "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+)
+
+// The tasks of Mavenlink sub-tasks, along with the sub-tasks whose tasks couldn't be retrieved
+type TasksInSubTasks struct {
+	Tasks            []mavenlink.Task
+	FailedSubTaskIds []string
+}
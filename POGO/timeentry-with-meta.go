@@ -0,0 +1,13 @@
+package POGO
+
+import (
+	mavenlink "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+)
+
+// A Mavenlink time entry along with the JIRA worklog it originates from
+type TimeentryWithMeta struct {
+	Timeentry     mavenlink.Timeentry
+	JiraIssueId   string
+	JiraWorklogId string
+	JiraUserEmail string
+}
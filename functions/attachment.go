@@ -0,0 +1,62 @@
+package functions
+
+import (
+	"fmt"
+	mavenlinkCommunicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	datasourceCommunicator "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
+	"github.com/desertjinn/mavenlink-jira-sync/POGO"
+	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"golang.org/x/net/context"
+	"strconv"
+)
+
+type AttachmentFunctionsInterface interface {
+	GetAttachmentsToBeProcessed(issuesAndTasks *POGO.IssueAndTask) []*mavenlinkCommunicator.Attachment
+}
+
+type AttachmentFunctions struct {
+	cf CommonFunctions
+	// Context of the run the functions are bound to
+	Context context.Context
+}
+
+// Check if a Mavenlink attachment exists in the datasource
+func doesAttachmentExistInDataSource(ctx context.Context,
+	attachment int32) *datasourceCommunicator.ExternalAttachments {
+
+	externalAttachment := &datasourceCommunicator.ExternalAttachments{}
+	externalAttachment.Source2AttachmentId = attachment
+	attachmentResponse, attachmentResponseErr :=
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetAttachment(
+			ctx, externalAttachment)
+	if attachmentResponseErr == nil && attachmentResponse.Error == nil && attachmentResponse.Attachment != nil {
+		if attachmentResponse.Attachment.Id != 0 {
+			return attachmentResponse.Attachment
+		}
+	}
+	return nil
+}
+
+// Get the Mavenlink attachments that are yet to be uploaded to JIRA and are within the size limit
+func (self *AttachmentFunctions) GetAttachmentsToBeProcessed(
+	issuesAndTasks *POGO.IssueAndTask) []*mavenlinkCommunicator.Attachment {
+
+	var attachments []*mavenlinkCommunicator.Attachment
+	for _, attachment := range issuesAndTasks.GetAttachments() {
+		attachmentId64, attachmentIdErr := strconv.ParseInt(attachment.Id, 10, 32)
+		if attachmentIdErr != nil {
+			continue
+		}
+		if doesAttachmentExistInDataSource(getRunContext(self.Context), int32(attachmentId64)) != nil {
+			continue
+		}
+		if attachment.Size > utility.MaxAttachmentSize {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+				fmt.Sprintf("Skipping attachment '%s' as it is larger than %d bytes", attachment.FileName,
+					utility.MaxAttachmentSize))
+			continue
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments
+}
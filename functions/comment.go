@@ -0,0 +1,147 @@
+package functions
+
+import (
+	"fmt"
+	mavenlinkCommunicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	datasourceCommunicator "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
+	"github.com/desertjinn/mavenlink-jira-sync/POGO"
+	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"golang.org/x/net/context"
+	"strconv"
+	"time"
+)
+
+// Layout a post's update time is saved to the datasource in, in UTC as the datasource keeps no offset
+const postUpdatedDbLayout = "2006-01-02 15:04:05"
+
+// Layouts a post's update time is found in, as returned by Mavenlink or as reformatted by the datasource
+var postUpdatedLayouts = []string{time.RFC3339, postUpdatedDbLayout}
+
+type CommentFunctionsInterface interface {
+	GetPostsToBeProcessedAsComments(allPosts []*mavenlinkCommunicator.Post, toBeCreated bool) (
+		[]*mavenlinkCommunicator.Post, map[string]*datasourceCommunicator.ExternalComments)
+	PrepareCommentsForCreation(issuesAndTasks *POGO.IssueAndTask) (<-chan POGO.CommentWithMeta, <-chan bool)
+	PrepareCommentsForUpdate(issuesAndTasks *POGO.IssueAndTask) (<-chan POGO.CommentWithMeta, <-chan bool)
+}
+
+type CommentFunctions struct {
+	cf CommonFunctions
+	// Context of the run the functions are bound to
+	Context context.Context
+}
+
+// Check if a Mavenlink post exists in the datasource
+func doesPostExistInDataSource(ctx context.Context, post int32) *datasourceCommunicator.ExternalComments {
+	externalComment := &datasourceCommunicator.ExternalComments{}
+	externalComment.Source2PostId = post
+	commentAndPostResponse, commentAndPostResponseErr :=
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetComment(ctx,
+			externalComment)
+	if commentAndPostResponseErr == nil && commentAndPostResponse.Error == nil &&
+		commentAndPostResponse.Comment != nil {
+		if commentAndPostResponse.Comment.Id != 0 {
+			return commentAndPostResponse.Comment
+		}
+	}
+	return nil
+}
+
+// Parse a post's update time from any of the layouts it's found in
+func parsePostUpdated(updatedAt string) (time.Time, bool) {
+	for _, layout := range postUpdatedLayouts {
+		if parsed, parsedErr := time.Parse(layout, updatedAt); parsedErr == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Check if a post was updated after its comment was synced, comparing the instants rather than their formatting
+func isPostUpdated(syncedUpdatedAt string, updatedAt string) bool {
+	synced, syncedOk := parsePostUpdated(syncedUpdatedAt)
+	updated, updatedOk := parsePostUpdated(updatedAt)
+	if !syncedOk || !updatedOk {
+		return syncedUpdatedAt != updatedAt
+	}
+	return !synced.Equal(updated)
+}
+
+func prepComment(post *mavenlinkCommunicator.Post, commentId string) *POGO.CommentWithMeta {
+	comment := new(POGO.CommentWithMeta)
+	if len(commentId) > 0 {
+		comment.Id = commentId
+	}
+	author := "Unknown"
+	if post.User != nil && len(post.User.FullName) > 0 {
+		author = post.User.FullName
+	}
+	comment.Body = fmt.Sprintf("*%s* wrote on Mavenlink(%s):\n\n%s", author, post.CreatedAt, post.Message)
+	comment.MavenlinkPostId = post.Id
+	comment.MavenlinkTaskId = post.StoryId
+	comment.MavenlinkPostUpdated = post.UpdatedAt
+
+	return comment
+}
+
+// Get the Mavenlink posts to be processed as JIRA comments
+func (self *CommentFunctions) GetPostsToBeProcessedAsComments(allPosts []*mavenlinkCommunicator.Post,
+	toBeCreated bool) ([]*mavenlinkCommunicator.Post, map[string]*datasourceCommunicator.ExternalComments) {
+
+	var posts []*mavenlinkCommunicator.Post
+	comments := map[string]*datasourceCommunicator.ExternalComments{}
+	for _, post := range allPosts {
+		postId64, postIdErr := strconv.ParseInt(post.Id, 10, 32)
+		if postIdErr != nil {
+			continue
+		}
+		comment := doesPostExistInDataSource(getRunContext(self.Context), int32(postId64))
+		if toBeCreated == true {
+			if comment == nil {
+				posts = append(posts, post)
+			}
+		} else {
+			if comment != nil {
+				posts = append(posts, post)
+				comments[post.Id] = comment
+			}
+		}
+	}
+	return posts, comments
+}
+
+// Prepare Mavenlink task posts as JIRA issue comments for creation purposes
+func (self *CommentFunctions) PrepareCommentsForCreation(issuesAndTasks *POGO.IssueAndTask) (
+	<-chan POGO.CommentWithMeta, <-chan bool) {
+
+	commentsChannel := make(chan POGO.CommentWithMeta)
+	commentsChannelClosed := make(chan bool)
+	go func() {
+		toBeCreated, _ := self.GetPostsToBeProcessedAsComments(issuesAndTasks.GetPosts(), true)
+		for _, toBe := range toBeCreated {
+			preppedComment := prepComment(toBe, "")
+			commentsChannel <- *preppedComment
+		}
+		commentsChannelClosed <- true
+	}()
+	return commentsChannel, commentsChannelClosed
+}
+
+// Prepare edited Mavenlink task posts as existing JIRA issue comments for update purposes
+func (self *CommentFunctions) PrepareCommentsForUpdate(issuesAndTasks *POGO.IssueAndTask) (
+	<-chan POGO.CommentWithMeta, <-chan bool) {
+
+	commentsChannel := make(chan POGO.CommentWithMeta)
+	commentsChannelClosed := make(chan bool)
+	go func() {
+		toBeSynced, relatedComments := self.GetPostsToBeProcessedAsComments(issuesAndTasks.GetPosts(), false)
+		for _, toBe := range toBeSynced {
+			existingComment := relatedComments[toBe.Id]
+			if isPostUpdated(existingComment.Source2UpdatedDtTm, toBe.UpdatedAt) {
+				preppedComment := prepComment(toBe, fmt.Sprint(existingComment.Source1CommentId))
+				commentsChannel <- *preppedComment
+			}
+		}
+		commentsChannelClosed <- true
+	}()
+	return commentsChannel, commentsChannelClosed
+}
@@ -1,10 +1,14 @@
 package functions
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	jiraCommunicator "github.com/desertjinn/jira-communicator/proto/jira-communicator"
 	synchronizer "github.com/desertjinn/mavenlink-jira-sync/proto/mavenlink-jira-sync"
 	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"golang.org/x/net/context"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -22,18 +26,31 @@ type CommonFunctionsInterface interface {
 	ParseMavenlinkDateToJiraDate(mavenlinkDate string, time string) string
 	ParseJiraDateToMavenlinkDate(jiraDate string) string
 	ParseDateForInsertingInDb(aDate string) string
+	ParsePostUpdatedForInsertingInDb(updatedAt string) string
 	ChangeDetected(existing string, detected string, mavenlink string, equivalenceType *synchronizer.EquivalenceTypes) bool
 	IsEquivalentToJira(jira string, mavenlink string, equivalenceType *synchronizer.EquivalenceTypes) bool
-	GetDefaultEquivalentJiraIssueType() (equivalentIssueType *jiraCommunicator.IssueType)
-	GetDefaultEquivalentJiraIssueStatus() (equivalentIssueStatus *jiraCommunicator.Status)
-	GetDefaultEquivalentJiraIssuePriority() (equivalentIssuePriority *jiraCommunicator.Priority)
-	GetJiraIssueTypeFromMetadata(mavenlinkIssueTypeName string, existingJiraIssueType string) (detectedIssueType *jiraCommunicator.IssueType)
-	GetJiraStatusFromMetadata(mavenlinkStatusName string, existingJiraStatus string) (detectedStatus *jiraCommunicator.Status)
-	GetJiraPriorityFromMetadata(mavenlinkPriorityName string, existingJiraPriority string) (detectedPriority *jiraCommunicator.Priority)
+	GetDefaultEquivalentJiraIssueType(externalProjectId int32) (equivalentIssueType *jiraCommunicator.IssueType)
+	GetDefaultEquivalentJiraIssueStatus(externalProjectId int32) (equivalentIssueStatus *jiraCommunicator.Status)
+	GetDefaultEquivalentJiraIssuePriority(externalProjectId int32) (equivalentIssuePriority *jiraCommunicator.Priority)
+	GetJiraIssueTypeFromMetadata(mavenlinkIssueTypeName string, existingJiraIssueType string, externalProjectId int32) (detectedIssueType *jiraCommunicator.IssueType)
+	GetJiraStatusFromMetadata(mavenlinkStatusName string, existingJiraStatus string, externalProjectId int32) (detectedStatus *jiraCommunicator.Status)
+	GetJiraPriorityFromMetadata(mavenlinkPriorityName string, existingJiraPriority string, externalProjectId int32) (detectedPriority *jiraCommunicator.Priority)
+	GetMavenlinkStatusFromJiraStatus(jiraStatusName string, externalProjectId int32) string
+	GetContentHash(content io.Reader) (string, error)
+	GetMilestonesFromConfiguration(milestones string) []string
+	GetIssueNumberFromKey(projectKey string, issueKey string) int32
 }
 
 type CommonFunctions struct {}
 
+// Get the context of the run functions are bound to, the process' one when they aren't bound to a run
+func getRunContext(runContext context.Context) context.Context {
+	if runContext != nil {
+		return runContext
+	}
+	return utility.GetUtilitiesSingleton().CommsContext
+}
+
 
 
 // Convert a date from the described layout to the desired format
@@ -50,72 +67,19 @@ func convertDateWithLayoutToFormat(date string, layout string, format string) st
 }
 
 
+// Get the external project's equivalence relation of the requested type, listed in order of preference
 func determineEquivalenceType(equivalenceType *synchronizer.EquivalenceTypes) (equivalence map[string][]string) {
+	relations := utility.GetUtilitiesSingleton().GetProjectEquivalence(equivalenceType.ExternalProjectId)
 	if equivalenceType.IssueType {
-		return utility.GetMavenlinkToJiraIssueTypesEquivalence()
+		return relations["IssueType"]
 	} else if equivalenceType.Status {
-		return utility.GetMavenlinkToJiraStatusesEquivalence()
+		return relations["Status"]
 	} else if equivalenceType.Priority {
-		return utility.GetMavenlinkToJiraPrioritiesEquivalence()
+		return relations["Priority"]
 	}
 	return
 }
 
-func mavenlinkAndJiraMatchRules(jira string, mavenlink string) bool {
-	// Issue types
-	if strings.EqualFold(mavenlink, "issue") {
-		if strings.EqualFold(jira, "Bug") {
-			return true
-		}
-	}
-	if strings.EqualFold(mavenlink, "task") {
-		return strings.EqualFold(jira, mavenlink)
-	}
-	// Statuses
-	if strings.EqualFold(mavenlink, "not started") {
-		if strings.EqualFold(jira, "open") {
-			return true
-		}
-	}
-	if strings.EqualFold(mavenlink, "started") {
-		if strings.EqualFold(jira, "in progress") {
-			return true
-		}
-	}
-	if strings.EqualFold(mavenlink, "completed") {
-		if strings.EqualFold(jira, "closed") {
-			return true
-		}
-	}
-	if strings.EqualFold(mavenlink, "needs info") {
-		if strings.EqualFold(jira, "Require Feedback") {
-			return true
-		}
-	}
-	// Priorities
-	if strings.EqualFold(mavenlink, "high") {
-		if strings.EqualFold(jira, "major") {
-			return true
-		}
-	}
-	if strings.EqualFold(mavenlink, "critical") {
-		if strings.EqualFold(jira, "blocker") {
-			return true
-		}
-	}
-	if strings.EqualFold(mavenlink, "normal") {
-		if strings.EqualFold(jira, "minor") {
-			return true
-		}
-	}
-	if strings.EqualFold(mavenlink, "low") {
-		if strings.EqualFold(jira, "trivial") {
-			return true
-		}
-	}
-	return false
-}
-
 // Get string of a float
 func (cf *CommonFunctions) FloatToString(input float64) string {
 	// to convert a float number to a string with the fewest digits necessary to accurately represent the float
@@ -209,10 +173,20 @@ func (cf *CommonFunctions) ParseDateForInsertingInDb(aDate string) string {
 	return convertDateWithLayoutToFormat(aDate, "2006-01-02T03:04:05Z", "2006-01-02 03:04:05")
 }
 
+// Convert a Mavenlink post's update time, whatever its offset, to a UTC MySQL date(yyyy-MM-dd HH:mm:ss) for
+// insertion, leaving one that can't be parsed as it is
+func (cf *CommonFunctions) ParsePostUpdatedForInsertingInDb(updatedAt string) string {
+	updated, parsed := parsePostUpdated(updatedAt)
+	if !parsed {
+		return updatedAt
+	}
+	return updated.UTC().Format(postUpdatedDbLayout)
+}
+
 func (cf *CommonFunctions) ChangeDetected(existing string, detected string, mavenlink string,
 	equivalenceType *synchronizer.EquivalenceTypes) bool {
 	equivalence := determineEquivalenceType(equivalenceType)
-	if jiraIssueTypes, ok := equivalence[mavenlink]; ok {
+	if jiraIssueTypes, ok := equivalence[strings.ToLower(mavenlink)]; ok {
 		if !strings.EqualFold(existing, detected) && !cf.StringInSlice(existing, jiraIssueTypes) {
 			return true
 		}
@@ -224,61 +198,53 @@ func (cf *CommonFunctions) IsEquivalentToJira(jira string, mavenlink string,
 	equivalenceType *synchronizer.EquivalenceTypes) bool {
 
 	equivalence := determineEquivalenceType(equivalenceType)
-	if jiraIssueTypes, ok := equivalence[mavenlink]; ok {
+	if jiraIssueTypes, ok := equivalence[strings.ToLower(mavenlink)]; ok {
 		return cf.StringInSlice(jira, jiraIssueTypes)
 	}
 	return false
 }
 
-func (cf *CommonFunctions) GetDefaultEquivalentJiraIssueType() (equivalentIssueType *jiraCommunicator.IssueType) {
-	for _, issuetype := range utility.GetUtilitiesSingleton().JiraIssueTypes {
-		if cf.IsEquivalentToJira(issuetype.Name, "task", &synchronizer.EquivalenceTypes{IssueType: true}) {
-			if mavenlinkAndJiraMatchRules(issuetype.Name, "task") {
-				return issuetype
-			}
-		}
-	}
-	return
+func (cf *CommonFunctions) GetDefaultEquivalentJiraIssueType(externalProjectId int32) (
+	equivalentIssueType *jiraCommunicator.IssueType) {
+
+	return cf.getEquivalentJiraIssueType("task", externalProjectId)
 }
 
-func (cf *CommonFunctions) GetDefaultEquivalentJiraIssueStatus() (equivalentIssueStatus *jiraCommunicator.Status) {
-	for _, issueStatus := range utility.GetUtilitiesSingleton().JiraStatuses {
-		if cf.IsEquivalentToJira(issueStatus.Name, "not started",
-			&synchronizer.EquivalenceTypes{Status: true}) {
+func (cf *CommonFunctions) GetDefaultEquivalentJiraIssueStatus(externalProjectId int32) (
+	equivalentIssueStatus *jiraCommunicator.Status) {
 
-			return issueStatus
-		}
-	}
-	return
+	return cf.getEquivalentJiraIssueStatus("not started", externalProjectId)
 }
 
-func (cf *CommonFunctions) GetDefaultEquivalentJiraIssuePriority() (equivalentIssuePriority *jiraCommunicator.Priority) {
-	for _, priority := range utility.GetUtilitiesSingleton().JiraPriorities {
-		if cf.IsEquivalentToJira(priority.Name, "high", &synchronizer.EquivalenceTypes{Priority: true}) {
-			return priority
-		}
-	}
-	return
+func (cf *CommonFunctions) GetDefaultEquivalentJiraIssuePriority(externalProjectId int32) (
+	equivalentIssuePriority *jiraCommunicator.Priority) {
+
+	return cf.getEquivalentJiraIssuePriority("high", externalProjectId)
 }
 
-func (cf *CommonFunctions) getEquivalentJiraIssuePriority(mavenlinkPriorityName string) (
+func (cf *CommonFunctions) getEquivalentJiraIssuePriority(mavenlinkPriorityName string, externalProjectId int32) (
 	equivalentIssuePriority *jiraCommunicator.Priority) {
 
-	for _, priority := range utility.GetUtilitiesSingleton().JiraPriorities {
-		if cf.IsEquivalentToJira(priority.Name, mavenlinkPriorityName, &synchronizer.EquivalenceTypes{Priority: true}) {
-			return priority
+	equivalence := determineEquivalenceType(&synchronizer.EquivalenceTypes{Priority: true,
+		ExternalProjectId: externalProjectId})
+	for _, jiraPriorityName := range equivalence[strings.ToLower(mavenlinkPriorityName)] {
+		for _, priority := range utility.GetUtilitiesSingleton().GetJiraPriorities() {
+			if strings.EqualFold(priority.Name, jiraPriorityName) {
+				return priority
+			}
 		}
 	}
 	return
 }
 
-
-func (cf *CommonFunctions) getEquivalentJiraIssueType(mavenlinkIssueTypeName string) (
+func (cf *CommonFunctions) getEquivalentJiraIssueType(mavenlinkIssueTypeName string, externalProjectId int32) (
 	equivalentIssueType *jiraCommunicator.IssueType) {
 
-	for _, issueType := range utility.GetUtilitiesSingleton().JiraIssueTypes {
-		if cf.IsEquivalentToJira(issueType.Name, mavenlinkIssueTypeName, &synchronizer.EquivalenceTypes{IssueType: true}) {
-			if mavenlinkAndJiraMatchRules(issueType.Name, mavenlinkIssueTypeName) {
+	equivalence := determineEquivalenceType(&synchronizer.EquivalenceTypes{IssueType: true,
+		ExternalProjectId: externalProjectId})
+	for _, jiraIssueTypeName := range equivalence[strings.ToLower(mavenlinkIssueTypeName)] {
+		for _, issueType := range utility.GetUtilitiesSingleton().GetJiraIssueTypes() {
+			if strings.EqualFold(issueType.Name, jiraIssueTypeName) {
 				return issueType
 			}
 		}
@@ -286,12 +252,16 @@ func (cf *CommonFunctions) getEquivalentJiraIssueType(mavenlinkIssueTypeName str
 	return
 }
 
-func (cf *CommonFunctions) getEquivalentJiraIssueStatus(mavenlinkStatusName string) (
+func (cf *CommonFunctions) getEquivalentJiraIssueStatus(mavenlinkStatusName string, externalProjectId int32) (
 	equivalentIssueStatus *jiraCommunicator.Status) {
 
-	for _, issueStatus := range utility.GetUtilitiesSingleton().JiraStatuses {
-		if cf.IsEquivalentToJira(issueStatus.Name, mavenlinkStatusName, &synchronizer.EquivalenceTypes{Status: true}) {
-			return issueStatus
+	equivalence := determineEquivalenceType(&synchronizer.EquivalenceTypes{Status: true,
+		ExternalProjectId: externalProjectId})
+	for _, jiraStatusName := range equivalence[strings.ToLower(mavenlinkStatusName)] {
+		for _, issueStatus := range utility.GetUtilitiesSingleton().GetJiraStatuses() {
+			if strings.EqualFold(issueStatus.Name, jiraStatusName) {
+				return issueStatus
+			}
 		}
 	}
 	return
@@ -299,15 +269,15 @@ func (cf *CommonFunctions) getEquivalentJiraIssueStatus(mavenlinkStatusName stri
 
 // Retrieve JIRA's issue type from Mavenlink task's StoryType value
 func (cf *CommonFunctions) GetJiraIssueTypeFromMetadata(mavenlinkIssueTypeName string,
-	existingJiraIssueType string) (detectedIssueType *jiraCommunicator.IssueType) {
+	existingJiraIssueType string, externalProjectId int32) (detectedIssueType *jiraCommunicator.IssueType) {
 
-	detectedIssueType = cf.getEquivalentJiraIssueType(mavenlinkIssueTypeName)
+	detectedIssueType = cf.getEquivalentJiraIssueType(mavenlinkIssueTypeName, externalProjectId)
 	if detectedIssueType != nil {
 		if len(existingJiraIssueType) == 0 {
 			return detectedIssueType
 		} else {
 			if cf.ChangeDetected(existingJiraIssueType, detectedIssueType.Name, mavenlinkIssueTypeName,
-				&synchronizer.EquivalenceTypes{IssueType: true}) {
+				&synchronizer.EquivalenceTypes{IssueType: true, ExternalProjectId: externalProjectId}) {
 
 				return detectedIssueType
 			}
@@ -318,15 +288,15 @@ func (cf *CommonFunctions) GetJiraIssueTypeFromMetadata(mavenlinkIssueTypeName s
 
 // Retrieve JIRA's status from Mavenlink task's State value
 func (cf *CommonFunctions) GetJiraStatusFromMetadata(mavenlinkStatusName string,
-	existingJiraStatus string) (detectedStatus *jiraCommunicator.Status) {
+	existingJiraStatus string, externalProjectId int32) (detectedStatus *jiraCommunicator.Status) {
 
-	detectedStatus = cf.getEquivalentJiraIssueStatus(mavenlinkStatusName)
+	detectedStatus = cf.getEquivalentJiraIssueStatus(mavenlinkStatusName, externalProjectId)
 	if detectedStatus != nil {
 		if len(existingJiraStatus) == 0 {
 			return detectedStatus
 		} else {
 			if cf.ChangeDetected(existingJiraStatus, detectedStatus.Name, mavenlinkStatusName,
-				&synchronizer.EquivalenceTypes{Status: true}) {
+				&synchronizer.EquivalenceTypes{Status: true, ExternalProjectId: externalProjectId}) {
 				return detectedStatus
 			}
 		}
@@ -336,15 +306,15 @@ func (cf *CommonFunctions) GetJiraStatusFromMetadata(mavenlinkStatusName string,
 
 // Retrieve JIRA's priority from Mavenlink task's priority value
 func (cf *CommonFunctions) GetJiraPriorityFromMetadata(mavenlinkPriorityName string,
-	existingJiraPriority string) (detectedPriority *jiraCommunicator.Priority) {
+	existingJiraPriority string, externalProjectId int32) (detectedPriority *jiraCommunicator.Priority) {
 
-	detectedPriority = cf.getEquivalentJiraIssuePriority(mavenlinkPriorityName)
+	detectedPriority = cf.getEquivalentJiraIssuePriority(mavenlinkPriorityName, externalProjectId)
 	if detectedPriority != nil {
 		if len(existingJiraPriority) == 0 {
 			return detectedPriority
 		} else {
 			if cf.ChangeDetected(existingJiraPriority, detectedPriority.Name, mavenlinkPriorityName,
-				&synchronizer.EquivalenceTypes{Priority: true}) {
+				&synchronizer.EquivalenceTypes{Priority: true, ExternalProjectId: externalProjectId}) {
 
 				return detectedPriority
 			}
@@ -352,3 +322,61 @@ func (cf *CommonFunctions) GetJiraPriorityFromMetadata(mavenlinkPriorityName str
 	}
 	return detectedPriority
 }
+
+// Retrieve Mavenlink's task state from JIRA's status value, as the reverse of the external project's status
+// equivalence. The state listing the status earliest wins, ties going to the default JIRA -> Mavenlink relation
+func (cf *CommonFunctions) GetMavenlinkStatusFromJiraStatus(jiraStatusName string, externalProjectId int32) string {
+	equivalence := determineEquivalenceType(&synchronizer.EquivalenceTypes{Status: true,
+		ExternalProjectId: externalProjectId})
+	defaultStatus := utility.GetJiraToMavenlinkStatusesEquivalence()[strings.ToLower(jiraStatusName)]
+	mavenlinkStatus := ""
+	preference := -1
+	for mavenlink, jiraStatuses := range equivalence {
+		for index, jira := range jiraStatuses {
+			if !strings.EqualFold(jira, jiraStatusName) {
+				continue
+			}
+			if preference == -1 || index < preference || (index == preference && mavenlinkStatus != defaultStatus &&
+				(mavenlink == defaultStatus || mavenlink < mavenlinkStatus)) {
+
+				mavenlinkStatus = mavenlink
+				preference = index
+			}
+			break
+		}
+	}
+	return mavenlinkStatus
+}
+
+// Get the SHA-256 hash of the content, read to its end, as a hex string
+func (cf *CommonFunctions) GetContentHash(content io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, hashErr := io.Copy(hash, content); hashErr != nil {
+		return "", hashErr
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Get the milestone task titles or IDs from a comma separated configuration, defaulting to "Construction"
+func (cf *CommonFunctions) GetMilestonesFromConfiguration(milestones string) []string {
+	var titlesOrIds []string
+	for _, milestone := range strings.Split(milestones, ",") {
+		if trimmed := strings.TrimSpace(milestone); len(trimmed) > 0 {
+			titlesOrIds = append(titlesOrIds, trimmed)
+		}
+	}
+	if len(titlesOrIds) == 0 {
+		titlesOrIds = append(titlesOrIds, utility.DefaultMilestone)
+	}
+	return titlesOrIds
+}
+
+// Get the number of a JIRA issue from its key(ie, 12 from PROJ-12), also accepting the bare number
+func (cf *CommonFunctions) GetIssueNumberFromKey(projectKey string, issueKey string) int32 {
+	issueNumber := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(issueKey)), strings.ToUpper(projectKey)+"-")
+	issueNumber64, issueNumber64Err := strconv.ParseInt(issueNumber, 10, 32)
+	if issueNumber64Err != nil {
+		return 0
+	}
+	return int32(issueNumber64)
+}
@@ -0,0 +1,221 @@
+package functions
+
+import (
+	jiraCommunicator "github.com/desertjinn/jira-communicator/proto/jira-communicator"
+	mavenlinkCommunicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/desertjinn/mavenlink-jira-sync/POGO"
+	synchronizer "github.com/desertjinn/mavenlink-jira-sync/proto/mavenlink-jira-sync"
+	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+)
+
+type EpicFunctionsInterface interface {
+	GetTasksToBeProcessed(subTasks []*mavenlinkCommunicator.Task, jiraEpics []*jiraCommunicator.Issue,
+		toBeCreated bool) ([]*mavenlinkCommunicator.Task,
+		map[string]*jiraCommunicator.Issue)
+	PrepareEpicsForCreation(sprintsAndTasks *POGO.SprintAndTask) (<-chan jiraCommunicator.IssueWithMeta, <-chan bool)
+	PrepareEpicsForUpdate(sprintsAndTasks *POGO.SprintAndTask) (<-chan jiraCommunicator.IssueWithMeta, <-chan bool)
+	GenerateEpicForCreation(project *jiraCommunicator.Project,
+		epic *jiraCommunicator.IssueWithMeta) *jiraCommunicator.IssueCreate
+	GenerateEpicForUpdate(project *jiraCommunicator.Project,
+		epic *jiraCommunicator.IssueWithMeta) *jiraCommunicator.IssueCreate
+}
+
+type EpicFunctions struct {
+	cf CommonFunctions
+	// Context of the run the functions are bound to
+	Context context.Context
+}
+
+// Get the JIRA epic related to the Mavenlink sub-task
+func getMatchingEpicForTask(ctx context.Context, epics []*jiraCommunicator.Issue,
+	task *mavenlinkCommunicator.Task) *jiraCommunicator.Issue {
+
+	taskId64, taskIdErr := strconv.ParseInt(task.Id, 10, 32)
+	if taskIdErr != nil {
+		return nil
+	}
+	taskInDb := doesTaskExistInDatasource(ctx, int32(taskId64))
+	if nil != taskInDb && taskInDb.Source1EpicId != 0 {
+		for _, epic := range epics {
+			epicId64, epicIdErr := strconv.ParseInt(epic.Id, 10, 32)
+			if epicIdErr != nil {
+				continue
+			}
+			if int32(epicId64) == taskInDb.Source1EpicId {
+				return epic
+			}
+		}
+	}
+	return nil
+}
+
+func prepEpic(task *mavenlinkCommunicator.Task, existingEpic *jiraCommunicator.Issue,
+	toBeUpdated bool) *jiraCommunicator.IssueWithMeta {
+
+	epic := new(jiraCommunicator.IssueWithMeta)
+	epic.Fields = new(jiraCommunicator.Fields)
+	epic.Fields.Status = new(jiraCommunicator.Status)
+	epic.ToBeUpdated = toBeUpdated
+	epic.Fields.Summary = task.Title
+	epic.Fields.Description = task.Description
+	epic.Fields.Duedate = task.DueDate
+	epic.Fields.Status.Name = task.State
+
+	taskId64, taskId64Err := strconv.ParseInt(task.Id, 10, 32)
+	parentTaskId64, parentTaskId64Err := strconv.ParseInt(task.ParentId, 10, 32)
+	if nil != taskId64Err || nil != parentTaskId64Err {
+		return nil
+	}
+	epic.MavenlinkTaskId = int32(taskId64)
+	epic.MavenlinkParentTaskId = int32(parentTaskId64)
+	if existingEpic != nil {
+		epic.Id = existingEpic.Id
+		epic.ExistingIssueKey = existingEpic.Key
+		if existingEpic.Fields != nil && existingEpic.Fields.Status != nil {
+			epic.ExistingIssueStatus = existingEpic.Fields.Status.Name
+		}
+	}
+	return epic
+}
+
+// Generate the custom fields of a JIRA epic, its name being the Mavenlink sub-task's title
+func getEpicCustomFields(epic *jiraCommunicator.IssueWithMeta) map[string]*jiraCommunicator.CustomFieldValue {
+	epicNameFieldId := utility.GetUtilitiesSingleton().GetJiraEpicNameFieldId()
+	if len(epicNameFieldId) == 0 {
+		return nil
+	}
+	return map[string]*jiraCommunicator.CustomFieldValue{
+		epicNameFieldId: {Values: []string{epic.Fields.Summary}},
+	}
+}
+
+// Get the Mavenlink sub-tasks to be processed as JIRA epics
+func (self *EpicFunctions) GetTasksToBeProcessed(subTasks []*mavenlinkCommunicator.Task,
+	jiraEpics []*jiraCommunicator.Issue, toBeCreated bool) ([]*mavenlinkCommunicator.Task,
+	map[string]*jiraCommunicator.Issue) {
+
+	var tasks []*mavenlinkCommunicator.Task
+	epics := map[string]*jiraCommunicator.Issue{}
+	for _, task := range subTasks {
+		epic := getMatchingEpicForTask(getRunContext(self.Context), jiraEpics, task)
+		if toBeCreated == true {
+			if epic == nil {
+				tasks = append(tasks, task)
+			}
+		} else {
+			if epic != nil {
+				tasks = append(tasks, task)
+				epics[task.Id] = epic
+			}
+		}
+	}
+	return tasks, epics
+}
+
+// Prepare Mavenlink sub-tasks as JIRA epics for creation purposes
+func (self *EpicFunctions) PrepareEpicsForCreation(sprintsAndTasks *POGO.SprintAndTask) (
+	<-chan jiraCommunicator.IssueWithMeta, <-chan bool) {
+
+	epicsChannel := make(chan jiraCommunicator.IssueWithMeta)
+	epicsChannelClosed := make(chan bool)
+	go func() {
+		toBeCreated, _ := self.GetTasksToBeProcessed(sprintsAndTasks.GetSubTasks(), sprintsAndTasks.GetEpics(),
+			true)
+		for _, toBe := range toBeCreated {
+			epic := prepEpic(toBe, nil, false)
+			if epic != nil {
+				epicsChannel <- *epic
+			}
+		}
+		epicsChannelClosed <- true
+	}()
+	return epicsChannel, epicsChannelClosed
+}
+
+// Prepare Mavenlink sub-tasks as existing JIRA epics for update purposes
+func (self *EpicFunctions) PrepareEpicsForUpdate(sprintsAndTasks *POGO.SprintAndTask) (
+	<-chan jiraCommunicator.IssueWithMeta, <-chan bool) {
+
+	epicsChannel := make(chan jiraCommunicator.IssueWithMeta)
+	epicsChannelClosed := make(chan bool)
+	go func() {
+		toBeSynced, relatedEpics := self.GetTasksToBeProcessed(sprintsAndTasks.GetSubTasks(),
+			sprintsAndTasks.GetEpics(), false)
+		for _, task := range toBeSynced {
+			relatedEpic := relatedEpics[task.Id]
+			if relatedEpic == nil || relatedEpic.Fields == nil {
+				continue
+			}
+			var toBeUpdated bool
+			if !strings.EqualFold(task.Title, relatedEpic.Fields.Summary) ||
+				!strings.EqualFold(task.Description, relatedEpic.Fields.Description) ||
+				!strings.EqualFold(task.DueDate, relatedEpic.Fields.Duedate) {
+
+				toBeUpdated = true
+			}
+			if relatedEpic.Fields.Status != nil &&
+				!self.cf.IsEquivalentToJira(relatedEpic.Fields.Status.Name, task.State,
+					&synchronizer.EquivalenceTypes{Status: true,
+						ExternalProjectId: sprintsAndTasks.GetExternalProjectId()}) {
+
+				toBeUpdated = true
+			}
+			if toBeUpdated {
+				epic := prepEpic(task, relatedEpic, toBeUpdated)
+				if epic != nil {
+					epicsChannel <- *epic
+				}
+			}
+		}
+		epicsChannelClosed <- true
+	}()
+	return epicsChannel, epicsChannelClosed
+}
+
+// Generate the JIRA issue object to be used for creating an epic
+func (self *EpicFunctions) GenerateEpicForCreation(project *jiraCommunicator.Project,
+	epic *jiraCommunicator.IssueWithMeta) *jiraCommunicator.IssueCreate {
+
+	createEpic := new(jiraCommunicator.IssueCreate)
+
+	createEpic.Fields = new(jiraCommunicator.FieldsForCreate)
+	createEpic.Fields.Summary = epic.Fields.Summary
+	createEpic.Fields.Description = epic.Fields.Description
+	createEpic.Fields.Duedate = epic.Fields.Duedate
+	createEpic.Fields.CustomFields = getEpicCustomFields(epic)
+
+	createEpic.Fields.Issuetype = new(jiraCommunicator.IssueType)
+	createEpic.Fields.Issuetype.Name = utility.JiraEpicIssueTypeName
+
+	createEpic.Fields.Project = new(jiraCommunicator.Project)
+	createEpic.Fields.Project.Key = project.Key
+
+	return createEpic
+}
+
+// Generate the JIRA issue object to be used to update an epic
+func (self *EpicFunctions) GenerateEpicForUpdate(project *jiraCommunicator.Project,
+	epic *jiraCommunicator.IssueWithMeta) *jiraCommunicator.IssueCreate {
+
+	if len(epic.ExistingIssueKey) == 0 {
+		return nil
+	}
+	updateEpic := new(jiraCommunicator.IssueCreate)
+
+	updateEpic.Id = epic.Id
+	updateEpic.Key = epic.ExistingIssueKey
+
+	updateEpic.Fields = new(jiraCommunicator.FieldsForCreate)
+	updateEpic.Fields.Summary = epic.Fields.Summary
+	updateEpic.Fields.Description = epic.Fields.Description
+	updateEpic.Fields.Duedate = epic.Fields.Duedate
+	updateEpic.Fields.CustomFields = getEpicCustomFields(epic)
+
+	updateEpic.Fields.Project = new(jiraCommunicator.Project)
+	updateEpic.Fields.Project.Key = project.Key
+
+	return updateEpic
+}
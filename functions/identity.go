@@ -0,0 +1,136 @@
+package functions
+
+import (
+	jiraCommunicator "github.com/desertjinn/jira-communicator/proto/jira-communicator"
+	mavenlinkCommunicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/desertjinn/mavenlink-jira-sync/POGO"
+	"strings"
+)
+
+type IdentityFunctionsInterface interface {
+	GetUnmatchedMavenlinkUsers(issuesAndTasks *POGO.IssueAndTask) []*mavenlinkCommunicator.User
+}
+
+type IdentityFunctions struct{}
+
+// Normalise an email address for comparison
+func normaliseEmailAddress(emailAddress string) string {
+	return strings.ToLower(strings.TrimSpace(emailAddress))
+}
+
+// Get the JIRA side of a Mavenlink email address, after applying its domain alias
+func getAliasedEmailAddress(emailAddress string, identities *POGO.IdentityMapping) string {
+	normalised := normaliseEmailAddress(emailAddress)
+	at := strings.LastIndex(normalised, "@")
+	if at < 0 {
+		return ""
+	}
+	alias := identities.GetDomainAlias(normalised[at+1:])
+	if len(alias) == 0 {
+		return ""
+	}
+	return normalised[:at+1] + alias
+}
+
+// Check if both JIRA users are the same, by account ID when available & by username otherwise
+func isSameJiraUser(a *jiraCommunicator.Author, b *jiraCommunicator.Author) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	if len(a.AccountId) > 0 && len(b.AccountId) > 0 {
+		return a.AccountId == b.AccountId
+	}
+	return len(a.Name) > 0 && strings.EqualFold(a.Name, b.Name)
+}
+
+// Get the JIRA user for a Mavenlink user from the explicit links, then the email address & then its domain alias
+func getJiraUserForMavenlinkUser(mavenlinkUser *mavenlinkCommunicator.User, jiraUsers []*jiraCommunicator.Author,
+	identities *POGO.IdentityMapping) *jiraCommunicator.Author {
+
+	if mavenlinkUser == nil {
+		return nil
+	}
+	if linked := identities.GetUser(mavenlinkUser.Id); linked != nil {
+		for _, jiraUser := range jiraUsers {
+			if isSameJiraUser(jiraUser, linked) {
+				return jiraUser
+			}
+		}
+		return linked
+	}
+	emailAddress := normaliseEmailAddress(mavenlinkUser.EmailAddress)
+	aliasedEmailAddress := getAliasedEmailAddress(mavenlinkUser.EmailAddress, identities)
+	if len(emailAddress) == 0 {
+		return nil
+	}
+	for _, jiraUser := range jiraUsers {
+		if normaliseEmailAddress(jiraUser.EmailAddress) == emailAddress {
+			return jiraUser
+		}
+	}
+	if len(aliasedEmailAddress) > 0 {
+		for _, jiraUser := range jiraUsers {
+			if normaliseEmailAddress(jiraUser.EmailAddress) == aliasedEmailAddress {
+				return jiraUser
+			}
+		}
+	}
+	return nil
+}
+
+// Get the Mavenlink user for a JIRA user from the explicit links, then the email address & then its domain alias
+func getMavenlinkUserForJiraUser(jiraUser *jiraCommunicator.Author, mavenlinkUsers []*mavenlinkCommunicator.User,
+	identities *POGO.IdentityMapping) *mavenlinkCommunicator.User {
+
+	if jiraUser == nil {
+		return nil
+	}
+	for mavenlinkUserId, linked := range identities.GetUsers() {
+		if isSameJiraUser(jiraUser, linked) {
+			for _, mavenlinkUser := range mavenlinkUsers {
+				if mavenlinkUser.Id == mavenlinkUserId {
+					return mavenlinkUser
+				}
+			}
+		}
+	}
+	emailAddress := normaliseEmailAddress(jiraUser.EmailAddress)
+	if len(emailAddress) == 0 {
+		return nil
+	}
+	for _, mavenlinkUser := range mavenlinkUsers {
+		if normaliseEmailAddress(mavenlinkUser.EmailAddress) == emailAddress {
+			return mavenlinkUser
+		}
+	}
+	for _, mavenlinkUser := range mavenlinkUsers {
+		if getAliasedEmailAddress(mavenlinkUser.EmailAddress, identities) == emailAddress {
+			return mavenlinkUser
+		}
+	}
+	return nil
+}
+
+// Get the Mavenlink assignees & time entry authors that can't be matched to a JIRA user
+func (self *IdentityFunctions) GetUnmatchedMavenlinkUsers(
+	issuesAndTasks *POGO.IssueAndTask) []*mavenlinkCommunicator.User {
+
+	var unmatched []*mavenlinkCommunicator.User
+	checked := map[string]bool{}
+	check := func(user *mavenlinkCommunicator.User) {
+		if user == nil || checked[user.Id] {
+			return
+		}
+		checked[user.Id] = true
+		if getJiraUserForMavenlinkUser(user, issuesAndTasks.GetUsers(), issuesAndTasks.GetIdentityMapping()) == nil {
+			unmatched = append(unmatched, user)
+		}
+	}
+	for _, task := range issuesAndTasks.GetTasks() {
+		check(task.User)
+	}
+	for _, timeentry := range issuesAndTasks.GetTimeentries() {
+		check(timeentry.User)
+	}
+	return unmatched
+}
@@ -5,7 +5,9 @@ import (
 	mavenlinkCommunicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
 	datasourceCommunicator "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
 	"github.com/desertjinn/mavenlink-jira-sync/POGO"
+	synchronizer "github.com/desertjinn/mavenlink-jira-sync/proto/mavenlink-jira-sync"
 	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"golang.org/x/net/context"
 	"regexp"
 	"strconv"
 	"strings"
@@ -17,24 +19,28 @@ type IssueFunctionsInterface interface {
 		map[string]*jiraCommunicator.Issue)
 	PrepareIssuesForCreation(issuesAndTasks *POGO.IssueAndTask) (<-chan jiraCommunicator.IssueWithMeta, <-chan bool)
 	PrepareIssuesForUpdate(issuesAndTasks *POGO.IssueAndTask) (<-chan jiraCommunicator.IssueWithMeta, <-chan bool)
-	GenerateIssueForCreation(project *jiraCommunicator.Project,
+	GenerateIssueForCreation(externalProjectId int32, project *jiraCommunicator.Project,
 		issue *jiraCommunicator.IssueWithMeta, sprintId string) *jiraCommunicator.IssueCreate
-	GenerateIssueForUpdate(project *jiraCommunicator.Project,
+	GenerateIssueForUpdate(externalProjectId int32, project *jiraCommunicator.Project,
 		issue jiraCommunicator.IssueWithMeta) *jiraCommunicator.IssueCreate
+	GetDeletedTasks(syncedTasks []*datasourceCommunicator.ExternalTasks,
+		allTasks []*mavenlinkCommunicator.Task) []*datasourceCommunicator.ExternalTasks
 }
 
 type IssueFunctions struct {
 	cf CommonFunctions
+	// Context of the run the functions are bound to
+	Context context.Context
 }
 
 // Check if JIRA issue and Mavenlink task combination exists in the datasource
-func doesIssueAndTaskExistInDatasource(task int32, issue int32) bool {
+func doesIssueAndTaskExistInDatasource(ctx context.Context, task int32, issue int32) bool {
 	var does bool
 	taskAndSprint := &datasourceCommunicator.ExternalTasks{}
 	taskAndSprint.Source1TaskId = issue
 	taskAndSprint.Source2TaskId = task
 	taskAndSprintResponse, taskAndSprintResponseErr :=
-		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTaskAndIssue(utility.GetUtilitiesSingleton().CommsContext,
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTaskAndIssue(ctx,
 			taskAndSprint)
 	if taskAndSprintResponseErr == nil && taskAndSprintResponse.Error == nil && taskAndSprintResponse.Task != nil {
 		if taskAndSprintResponse.Task.Id != 0 {
@@ -45,11 +51,13 @@ func doesIssueAndTaskExistInDatasource(task int32, issue int32) bool {
 }
 
 // Check if a Mavenlink task that is part of a Mavenlink sub-task(ie, a JIRA Sprint) exists in the datasource
-func doesTaskInSubTaskExistInDatasource(task int32) *datasourceCommunicator.ExternalTasks {
+func doesTaskInSubTaskExistInDatasource(ctx context.Context,
+	task int32) *datasourceCommunicator.ExternalTasks {
+
 	taskAndIssue := &datasourceCommunicator.ExternalTasks{}
 	taskAndIssue.Source2TaskId = task
 	taskAndIssueResponse, taskAndSprintResponseErr :=
-		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTaskInSubTaskFromId(utility.GetUtilitiesSingleton().CommsContext,
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTaskInSubTaskFromId(ctx,
 			taskAndIssue)
 	if taskAndSprintResponseErr == nil && taskAndIssueResponse.Error == nil && taskAndIssueResponse.Task != nil {
 		if taskAndIssueResponse.Task.Id != 0 {
@@ -60,14 +68,16 @@ func doesTaskInSubTaskExistInDatasource(task int32) *datasourceCommunicator.Exte
 }
 
 // Get the JIRA issue related to the Mavenlink task
-func getMatchingIssueForTask(issues []*jiraCommunicator.Issue, task *mavenlinkCommunicator.Task) *jiraCommunicator.Issue {
+func getMatchingIssueForTask(ctx context.Context, issues []*jiraCommunicator.Issue,
+	task *mavenlinkCommunicator.Task) *jiraCommunicator.Issue {
+
 	var taskId int32
 	taskId64, taskIdErr := strconv.ParseInt(task.Id, 10, 32)
 	if taskIdErr != nil {
 		return nil
 	}
 	taskId = int32(taskId64)
-	taskInDb := doesTaskInSubTaskExistInDatasource(taskId)
+	taskInDb := doesTaskInSubTaskExistInDatasource(ctx, taskId)
 	if nil != taskInDb {
 		for _, issue := range issues {
 			var issueId int32
@@ -77,7 +87,7 @@ func getMatchingIssueForTask(issues []*jiraCommunicator.Issue, task *mavenlinkCo
 			}
 			issueId = int32(issueId64)
 			if issueId == taskInDb.Source1TaskId {
-				exists := doesIssueAndTaskExistInDatasource(taskId, issueId)
+				exists := doesIssueAndTaskExistInDatasource(ctx, taskId, issueId)
 				if exists == true {
 					return issue
 				}
@@ -87,8 +97,62 @@ func getMatchingIssueForTask(issues []*jiraCommunicator.Issue, task *mavenlinkCo
 	return nil
 }
 
+// Get the values of a JIRA custom field by its ID
+func getCustomFieldValues(fields *jiraCommunicator.Fields, fieldId string) []string {
+	if fields == nil || fields.CustomFields == nil {
+		return nil
+	}
+	if customField, ok := fields.CustomFields[fieldId]; ok && customField != nil {
+		return customField.Values
+	}
+	return nil
+}
+
+// Get the JIRA remaining estimate for an original estimate, after the time already logged against the issue
+func getRemainingEstimate(originalEstimateSeconds int64, timeSpentSeconds int64) int64 {
+	if timeSpentSeconds >= originalEstimateSeconds {
+		return 0
+	}
+	return originalEstimateSeconds - timeSpentSeconds
+}
+
+// JIRA estimate that clears an issue's time tracking, zero seconds being dropped from the request
+const clearedJiraEstimate = "0m"
+
+// Get JIRA's time tracking from the Mavenlink task's budgeted hours(time estimate), nil when unchanged.
+// The task's budget is an amount of money, which JIRA's time tracking has no field for, so it isn't mapped.
+func getTimeTrackingForTask(task *mavenlinkCommunicator.Task,
+	existingIssue *jiraCommunicator.Issue) *jiraCommunicator.TimeTracking {
+
+	var existingTimeTracking *jiraCommunicator.TimeTracking
+	if existingIssue != nil && existingIssue.Fields != nil {
+		existingTimeTracking = existingIssue.Fields.Timetracking
+	}
+	if task.TimeEstimateInMinutes <= 0 {
+		// Clear the JIRA estimate when the Mavenlink estimate has been removed
+		if existingTimeTracking == nil || existingTimeTracking.OriginalEstimateSeconds <= 0 {
+			return nil
+		}
+		timeTracking := new(jiraCommunicator.TimeTracking)
+		timeTracking.OriginalEstimate = clearedJiraEstimate
+		timeTracking.RemainingEstimate = clearedJiraEstimate
+		return timeTracking
+	}
+	timeTracking := new(jiraCommunicator.TimeTracking)
+	timeTracking.OriginalEstimateSeconds = int64(task.TimeEstimateInMinutes) * 60
+	timeTracking.RemainingEstimateSeconds = timeTracking.OriginalEstimateSeconds
+	if existingTimeTracking != nil {
+		if existingTimeTracking.OriginalEstimateSeconds == timeTracking.OriginalEstimateSeconds {
+			return nil
+		}
+		timeTracking.RemainingEstimateSeconds = getRemainingEstimate(timeTracking.OriginalEstimateSeconds,
+			existingTimeTracking.TimeSpentSeconds)
+	}
+	return timeTracking
+}
+
 func prepIssue(task *mavenlinkCommunicator.Task, existingIssue *jiraCommunicator.Issue,
-	users []*jiraCommunicator.Author,
+	users []*jiraCommunicator.Author, identities *POGO.IdentityMapping,
 	issueType *jiraCommunicator.IssueType, issueStatus *jiraCommunicator.Status,
 	issuePriority *jiraCommunicator.Priority, toBeUpdated bool) *jiraCommunicator.IssueWithMeta {
 
@@ -104,14 +168,11 @@ func prepIssue(task *mavenlinkCommunicator.Task, existingIssue *jiraCommunicator
 	issue.Fields.Issuetype.Name = task.StoryType
 	issue.Fields.Status.Name = task.State
 	issue.Fields.Priority.Name = task.Priority
-	if task.User != nil {
-		for _, user := range users {
-			if strings.EqualFold(user.EmailAddress, task.User.EmailAddress) {
-				issue.Fields.Assignee = new(jiraCommunicator.Author)
-				issue.Fields.Assignee.Name = user.Name
-				break
-			}
-		}
+	issue.Fields.Timetracking = getTimeTrackingForTask(task, existingIssue)
+	if assignee := getJiraUserForMavenlinkUser(task.User, users, identities); assignee != nil {
+		issue.Fields.Assignee = new(jiraCommunicator.Author)
+		issue.Fields.Assignee.Name = assignee.Name
+		issue.Fields.Assignee.AccountId = assignee.AccountId
 	}
 
 	parentTaskId64, parentTaskId64Err := strconv.ParseInt(task.ParentId, 10, 32)
@@ -134,17 +195,21 @@ func prepIssue(task *mavenlinkCommunicator.Task, existingIssue *jiraCommunicator
 		if len(existingIssue.Id) > 0 {
 			issue.Id = existingIssue.Id
 		}
-		if len(existingIssue.Fields.Customfield_10722[0]) > 0 {
-			issue.JiraSprint = existingIssue.Fields.Customfield_10722[0]
+		sprints := getCustomFieldValues(existingIssue.Fields,
+			utility.GetUtilitiesSingleton().GetJiraSprintFieldId())
+		if len(sprints) > 0 && len(sprints[0]) > 0 {
+			issue.JiraSprint = sprints[0]
 			pat := regexp.MustCompile(`id=([0-9]+)]`)
-			apiSprintIdMatch := pat.FindStringSubmatch(existingIssue.Fields.Customfield_10722[0])
-			if 0 < len(apiSprintIdMatch[1]) {
+			apiSprintIdMatch := pat.FindStringSubmatch(sprints[0])
+			if 1 < len(apiSprintIdMatch) && 0 < len(apiSprintIdMatch[1]) {
 				issue.ExistingIssueSprintId = apiSprintIdMatch[1]
 			}
 		}
-		if len(existingIssue.Fields.Customfield_11022) > 0 {
-			issue.JiraEpic = existingIssue.Fields.Customfield_11022
-			issue.ExistingIssueEpicId = existingIssue.Fields.Customfield_11022
+		epics := getCustomFieldValues(existingIssue.Fields,
+			utility.GetUtilitiesSingleton().GetJiraEpicLinkFieldId())
+		if len(epics) > 0 && len(epics[0]) > 0 {
+			issue.JiraEpic = epics[0]
+			issue.ExistingIssueEpicId = epics[0]
 		}
 		if len(existingIssue.Key) > 0 {
 			issue.ExistingIssueKey = existingIssue.Key
@@ -162,7 +227,7 @@ func (self *IssueFunctions) GetTasksToBeProcessedAsIssues(allTasks []*mavenlinkC
 	var tasks []*mavenlinkCommunicator.Task
 	issues := map[string]*jiraCommunicator.Issue{}
 	for _, task := range allTasks {
-		issue := getMatchingIssueForTask(jiraIssues, task)
+		issue := getMatchingIssueForTask(getRunContext(self.Context), jiraIssues, task)
 		if toBeCreated == true {
 			if issue == nil {
 				tasks = append(tasks, task)
@@ -177,6 +242,23 @@ func (self *IssueFunctions) GetTasksToBeProcessedAsIssues(allTasks []*mavenlinkC
 	return tasks, issues
 }
 
+// Get the synced Mavenlink tasks that are no longer part of any sub-task
+func (self *IssueFunctions) GetDeletedTasks(syncedTasks []*datasourceCommunicator.ExternalTasks,
+	allTasks []*mavenlinkCommunicator.Task) []*datasourceCommunicator.ExternalTasks {
+
+	var deletedTasks []*datasourceCommunicator.ExternalTasks
+	existingTasks := map[int32]bool{}
+	for _, task := range allTasks {
+		existingTasks[self.cf.GetIdFromString(task.Id)] = true
+	}
+	for _, syncedTask := range syncedTasks {
+		if _, exists := existingTasks[syncedTask.Source2TaskId]; !exists {
+			deletedTasks = append(deletedTasks, syncedTask)
+		}
+	}
+	return deletedTasks
+}
+
 // Prepare Mavenlink sub-tasks as JIRA issues for creation purposes
 func (self *IssueFunctions) PrepareIssuesForCreation(issuesAndTasks *POGO.IssueAndTask) (
 	<-chan jiraCommunicator.IssueWithMeta, <-chan bool) {
@@ -187,19 +269,20 @@ func (self *IssueFunctions) PrepareIssuesForCreation(issuesAndTasks *POGO.IssueA
 		toBeCreated, _ := self.GetTasksToBeProcessedAsIssues(issuesAndTasks.GetTasks(), issuesAndTasks.GetIssues(),
 			true)
 		for _, toBe := range toBeCreated {
-			issueType := self.cf.GetJiraIssueTypeFromMetadata(toBe.StoryType, "")
+			issueType := self.cf.GetJiraIssueTypeFromMetadata(toBe.StoryType, "",
+				issuesAndTasks.GetExternalProjectId())
 			if issueType == nil {
-				issueType = self.cf.GetDefaultEquivalentJiraIssueType()
+				issueType = self.cf.GetDefaultEquivalentJiraIssueType(issuesAndTasks.GetExternalProjectId())
 			}
-			status := self.cf.GetJiraStatusFromMetadata(toBe.State, "")
+			status := self.cf.GetJiraStatusFromMetadata(toBe.State, "", issuesAndTasks.GetExternalProjectId())
 			if status == nil {
-				status = self.cf.GetDefaultEquivalentJiraIssueStatus()
+				status = self.cf.GetDefaultEquivalentJiraIssueStatus(issuesAndTasks.GetExternalProjectId())
 			}
-			priority := self.cf.GetJiraPriorityFromMetadata(toBe.Priority, "")
+			priority := self.cf.GetJiraPriorityFromMetadata(toBe.Priority, "", issuesAndTasks.GetExternalProjectId())
 			if priority == nil {
-				priority = self.cf.GetDefaultEquivalentJiraIssuePriority()
+				priority = self.cf.GetDefaultEquivalentJiraIssuePriority(issuesAndTasks.GetExternalProjectId())
 			}
-			issue := prepIssue(toBe, nil, issuesAndTasks.GetUsers(), issueType,
+			issue := prepIssue(toBe, nil, issuesAndTasks.GetUsers(), issuesAndTasks.GetIdentityMapping(), issueType,
 				status, priority, false)
 			if issue != nil {
 				issueChannel <- *issue
@@ -221,29 +304,43 @@ func (self *IssueFunctions) PrepareIssuesForUpdate(issuesAndTasks *POGO.IssueAnd
 			issuesAndTasks.GetIssues(), false)
 		for _, toBe := range toBeSynced {
 			existingIssue := relatedIssues[toBe.Id]
-			//issueType := GetJiraIssueTypeFromMetadata(toBe.StoryType, existingIssue.Fields.Issuetype.Name)
-			status := self.cf.GetJiraStatusFromMetadata(toBe.State, existingIssue.Fields.Status.Name)
-			priority := self.cf.GetJiraPriorityFromMetadata(toBe.Priority, existingIssue.Fields.Priority.Name)
+			var issueType *jiraCommunicator.IssueType
+			if existingIssue.Fields.Issuetype != nil {
+				issueType = self.cf.GetJiraIssueTypeFromMetadata(toBe.StoryType, existingIssue.Fields.Issuetype.Name,
+					issuesAndTasks.GetExternalProjectId())
+			}
+			status := self.cf.GetJiraStatusFromMetadata(toBe.State, existingIssue.Fields.Status.Name,
+				issuesAndTasks.GetExternalProjectId())
+			priority := self.cf.GetJiraPriorityFromMetadata(toBe.Priority, existingIssue.Fields.Priority.Name,
+				issuesAndTasks.GetExternalProjectId())
 			var toBeUpdated bool
 			if !strings.EqualFold(existingIssue.Fields.Summary, toBe.Title) ||
 				!strings.EqualFold(existingIssue.Fields.Description, toBe.Description) {
 
 				toBeUpdated = true
 			}
-			if existingIssue.Fields.Assignee != nil && toBe.User != nil &&
-				!strings.EqualFold(existingIssue.Fields.Assignee.EmailAddress, toBe.User.EmailAddress) {
+			assignee := getJiraUserForMavenlinkUser(toBe.User, issuesAndTasks.GetUsers(),
+				issuesAndTasks.GetIdentityMapping())
+			if assignee != nil && !isSameJiraUser(existingIssue.Fields.Assignee, assignee) {
 				toBeUpdated = true
 			}
-			if existingIssue.Fields.Assignee == nil && toBe.User != nil {
+			if getTimeTrackingForTask(toBe, existingIssue) != nil {
 				toBeUpdated = true
 			}
-			//if issueType != nil && !strings.EqualFold(issueType.Name, toBe.StoryType) {
-			//	toBeUpdated = true
-			//}
-			//issue := prepIssue(toBe, existingIssue, issuesAndTasks.GetUsers(), issueType,
-			//	status, priority, toBeUpdated)
-			issue := prepIssue(toBe, existingIssue, issuesAndTasks.GetUsers(), nil,
-				status, priority, toBeUpdated)
+			if status != nil && existingIssue.Fields.Status != nil &&
+				!self.cf.IsEquivalentToJira(existingIssue.Fields.Status.Name, toBe.State,
+					&synchronizer.EquivalenceTypes{Status: true,
+						ExternalProjectId: issuesAndTasks.GetExternalProjectId()}) {
+				toBeUpdated = true
+			}
+			if issueType != nil &&
+				!self.cf.IsEquivalentToJira(existingIssue.Fields.Issuetype.Name, toBe.StoryType,
+					&synchronizer.EquivalenceTypes{IssueType: true,
+						ExternalProjectId: issuesAndTasks.GetExternalProjectId()}) {
+				toBeUpdated = true
+			}
+			issue := prepIssue(toBe, existingIssue, issuesAndTasks.GetUsers(), issuesAndTasks.GetIdentityMapping(),
+				issueType, status, priority, toBeUpdated)
 			if issue != nil {
 				issueChannel <- *issue
 			}
@@ -254,12 +351,15 @@ func (self *IssueFunctions) PrepareIssuesForUpdate(issuesAndTasks *POGO.IssueAnd
 }
 
 // Generate the JIRA issue object to be used for creating an issue
-func (self *IssueFunctions) GenerateIssueForCreation(project *jiraCommunicator.Project,
+func (self *IssueFunctions) GenerateIssueForCreation(externalProjectId int32, project *jiraCommunicator.Project,
 	issue *jiraCommunicator.IssueWithMeta, sprintId string) *jiraCommunicator.IssueCreate {
 
-	issueType := self.cf.GetJiraIssueTypeFromMetadata(issue.Fields.Issuetype.Name, issue.ExistingIssueType)
-	status := self.cf.GetJiraStatusFromMetadata(issue.Fields.Status.Name, issue.ExistingIssueStatus)
-	priority := self.cf.GetJiraPriorityFromMetadata(issue.Fields.Priority.Name, issue.ExistingIssuePriority)
+	issueType := self.cf.GetJiraIssueTypeFromMetadata(issue.Fields.Issuetype.Name, issue.ExistingIssueType,
+		externalProjectId)
+	status := self.cf.GetJiraStatusFromMetadata(issue.Fields.Status.Name, issue.ExistingIssueStatus,
+		externalProjectId)
+	priority := self.cf.GetJiraPriorityFromMetadata(issue.Fields.Priority.Name, issue.ExistingIssuePriority,
+		externalProjectId)
 	if issueType != nil && priority != nil && status != nil {
 		createIssue := new(jiraCommunicator.IssueCreate)
 
@@ -267,11 +367,18 @@ func (self *IssueFunctions) GenerateIssueForCreation(project *jiraCommunicator.P
 		createIssue.Fields.Summary = issue.Fields.Summary
 		createIssue.Fields.Description = issue.Fields.Description
 		createIssue.Fields.Duedate = issue.Fields.Duedate
-		createIssue.Fields.Customfield_10722 = sprintId
+		createIssue.Fields.Timetracking = issue.Fields.Timetracking
+		// Issues of projects in epic mode aren't part of a sprint
+		if len(sprintId) > 0 {
+			createIssue.Fields.CustomFields = map[string]*jiraCommunicator.CustomFieldValue{
+				utility.GetUtilitiesSingleton().GetJiraSprintFieldId(): {Values: []string{sprintId}},
+			}
+		}
 
 		if issue.Fields.Assignee != nil {
 			createIssue.Fields.Assignee = new(jiraCommunicator.Author)
 			createIssue.Fields.Assignee.Name = issue.Fields.Assignee.Name
+			createIssue.Fields.Assignee.AccountId = issue.Fields.Assignee.AccountId
 		}
 
 		createIssue.Fields.Issuetype = new(jiraCommunicator.IssueType)
@@ -295,13 +402,13 @@ func (self *IssueFunctions) GenerateIssueForCreation(project *jiraCommunicator.P
 }
 
 // Generate the JIRA issue object to be used to update an issue
-func (self *IssueFunctions) GenerateIssueForUpdate(project *jiraCommunicator.Project,
+func (self *IssueFunctions) GenerateIssueForUpdate(externalProjectId int32, project *jiraCommunicator.Project,
 	issue jiraCommunicator.IssueWithMeta) *jiraCommunicator.IssueCreate {
 
-	issueType := self.cf.GetJiraIssueTypeFromMetadata(issue.Fields.Issuetype.Name, issue.ExistingIssueType)
-	status := self.cf.GetJiraStatusFromMetadata(issue.Fields.Status.Name, issue.ExistingIssueStatus)
-	priority := self.cf.GetJiraPriorityFromMetadata(issue.Fields.Priority.Name, issue.ExistingIssuePriority)
-	if issueType != nil && priority != nil && status != nil {
+	priority := self.cf.GetJiraPriorityFromMetadata(issue.Fields.Priority.Name, issue.ExistingIssuePriority,
+		externalProjectId)
+	// Status & issue type aren't part of an edit, they're changed through workflow transitions & moves
+	if priority != nil {
 		updateIssue := new(jiraCommunicator.IssueCreate)
 
 		updateIssue.Id = issue.Id
@@ -311,24 +418,18 @@ func (self *IssueFunctions) GenerateIssueForUpdate(project *jiraCommunicator.Pro
 		updateIssue.Fields.Summary = issue.Fields.Summary
 		updateIssue.Fields.Description = issue.Fields.Description
 		updateIssue.Fields.Duedate = issue.Fields.Duedate
+		updateIssue.Fields.Timetracking = issue.Fields.Timetracking
 
 		if issue.Fields.Assignee != nil {
 			updateIssue.Fields.Assignee = new(jiraCommunicator.Author)
 			updateIssue.Fields.Assignee.Name = issue.Fields.Assignee.Name
+			updateIssue.Fields.Assignee.AccountId = issue.Fields.Assignee.AccountId
 		}
 
-		updateIssue.Fields.Issuetype = new(jiraCommunicator.IssueType)
-		updateIssue.Fields.Issuetype.Name = ""
-		updateIssue.Fields.Issuetype.Id = issueType.Id
-
 		updateIssue.Fields.Priority = new(jiraCommunicator.Priority)
 		updateIssue.Fields.Priority.Name = ""
 		updateIssue.Fields.Priority.Id = priority.Id
 
-		updateIssue.Fields.Status = new(jiraCommunicator.Status)
-		updateIssue.Fields.Status.Name = ""
-		updateIssue.Fields.Status.Id = status.Id
-
 		return updateIssue
 	}
 	return nil
@@ -6,9 +6,13 @@ import (
 	mavenlinkCommunicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
 	datasourceCommunicator "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
 	"github.com/desertjinn/mavenlink-jira-sync/POGO"
+	synchronizer "github.com/desertjinn/mavenlink-jira-sync/proto/mavenlink-jira-sync"
 	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type SprintFunctionsInterface interface {
@@ -17,20 +21,24 @@ type SprintFunctionsInterface interface {
 		map[string]*jiraCommunicator.Sprint)
 	PrepareSprintsForCreation(sprintsAndTasks *POGO.SprintAndTask) (<-chan jiraCommunicator.SprintWithMeta, <-chan bool)
 	PrepareSprintsForUpdate(sprintsAndTasks *POGO.SprintAndTask) (<-chan jiraCommunicator.SprintWithMeta, <-chan bool)
+	GetConfiguredRapidView(rapidViews []*jiraCommunicator.GreenhopperRapidView,
+		board string) (*jiraCommunicator.GreenhopperRapidView, error)
 }
 
 type SprintFunctions struct {
 	cf CommonFunctions
+	// Context of the run the functions are bound to
+	Context context.Context
 }
 
 // Check if JIRA sprint and Mavenlink task combination exists in the datasource
-func doesSprintAndTaskExistInDatasource(task int32, sprint int32) bool {
+func doesSprintAndTaskExistInDatasource(ctx context.Context, task int32, sprint int32) bool {
 	var does bool
 	taskAndSprint := &datasourceCommunicator.ExternalTasks{}
 	taskAndSprint.Source1SprintId = sprint
 	taskAndSprint.Source2TaskId = task
 	taskAndSprintResponse, taskAndSprintResponseErr :=
-		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTaskAndSprint(utility.GetUtilitiesSingleton().CommsContext,
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTaskAndSprint(ctx,
 			taskAndSprint)
 	if taskAndSprintResponseErr == nil && taskAndSprintResponse.Error == nil && taskAndSprintResponse.Task != nil {
 		if taskAndSprintResponse.Task.Id != 0 {
@@ -41,11 +49,11 @@ func doesSprintAndTaskExistInDatasource(task int32, sprint int32) bool {
 }
 
 // Check if Mavenlink task exists in the datasource
-func doesTaskExistInDatasource(task int32) *datasourceCommunicator.ExternalTasks {
+func doesTaskExistInDatasource(ctx context.Context, task int32) *datasourceCommunicator.ExternalTasks {
 	taskAndSprint := &datasourceCommunicator.ExternalTasks{}
 	taskAndSprint.Source2TaskId = task
 	taskAndSprintResponse, taskAndSprintResponseErr :=
-		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTaskIfExists(utility.GetUtilitiesSingleton().CommsContext,
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTaskIfExists(ctx,
 			taskAndSprint)
 	if taskAndSprintResponseErr == nil && taskAndSprintResponse.Error == nil && taskAndSprintResponse.Task != nil {
 		if taskAndSprintResponse.Task.Id != 0 {
@@ -56,7 +64,7 @@ func doesTaskExistInDatasource(task int32) *datasourceCommunicator.ExternalTasks
 }
 
 // Get the JIRA sprint related to the Mavenlink task
-func getMatchingSprintForTask(sprints []*jiraCommunicator.Sprint,
+func getMatchingSprintForTask(ctx context.Context, sprints []*jiraCommunicator.Sprint,
 	task *mavenlinkCommunicator.Task) *jiraCommunicator.Sprint {
 
 	var taskId int32
@@ -65,11 +73,11 @@ func getMatchingSprintForTask(sprints []*jiraCommunicator.Sprint,
 		return nil
 	}
 	taskId = int32(taskId64)
-	taskInDb := doesTaskExistInDatasource(taskId)
+	taskInDb := doesTaskExistInDatasource(ctx, taskId)
 	if nil != taskInDb {
 		for _, sprint := range sprints {
 			if sprint.Id == taskInDb.Source1SprintId {
-				exists := doesSprintAndTaskExistInDatasource(taskId, sprint.Id)
+				exists := doesSprintAndTaskExistInDatasource(ctx, taskId, sprint.Id)
 				if exists == true {
 					return sprint
 				}
@@ -79,12 +87,37 @@ func getMatchingSprintForTask(sprints []*jiraCommunicator.Sprint,
 	return nil
 }
 
-func (self *SprintFunctions) prepSprint(task *mavenlinkCommunicator.Task, rapidView string, sprintId int32) *jiraCommunicator.SprintWithMeta {
+// Get the JIRA sprint state(future → active → closed) from the Mavenlink sub-task's state & dates
+func (self *SprintFunctions) getSprintStateForTask(task *mavenlinkCommunicator.Task, existingState string,
+	externalProjectId int32) string {
+
+	state := utility.SprintStateFuture
+	if self.cf.IsEquivalentToJira("closed", strings.ToLower(task.State),
+		&synchronizer.EquivalenceTypes{Status: true, ExternalProjectId: externalProjectId}) {
+
+		state = utility.SprintStateClosed
+	} else if len(task.StartDate) > 0 && task.StartDate <= time.Now().Format("2006-01-02") &&
+		!self.cf.IsEquivalentToJira("open", strings.ToLower(task.State),
+			&synchronizer.EquivalenceTypes{Status: true, ExternalProjectId: externalProjectId}) {
+
+		state = utility.SprintStateActive
+	}
+	// JIRA sprints can't move back to an earlier state
+	if strings.EqualFold(existingState, utility.SprintStateActive) && state == utility.SprintStateFuture {
+		state = utility.SprintStateActive
+	}
+	return state
+}
+
+func (self *SprintFunctions) prepSprint(task *mavenlinkCommunicator.Task, rapidView string, sprintId int32,
+	existingState string, externalProjectId int32) *jiraCommunicator.SprintWithMeta {
+
 	sprint := new(jiraCommunicator.SprintWithMeta)
 	if sprintId > 0 {
 		sprint.Id = sprintId
 	}
 	sprint.Name = task.Title
+	sprint.State = self.getSprintStateForTask(task, existingState, externalProjectId)
 	sprint.StartDate = self.cf.ParseMavenlinkDateToJiraDate(task.StartDate, "")
 	if task.StartDate == task.DueDate {
 		sprint.EndDate = self.cf.ParseMavenlinkDateToJiraDate(task.DueDate, "01:00:00")
@@ -105,6 +138,27 @@ func (self *SprintFunctions) prepSprint(task *mavenlinkCommunicator.Task, rapidV
 
 
 
+// Get the JIRA board(rapid view) named by ID or name in the sync configuration.
+// Without a configured board, the project's only board is used.
+func (self *SprintFunctions) GetConfiguredRapidView(rapidViews []*jiraCommunicator.GreenhopperRapidView,
+	board string) (*jiraCommunicator.GreenhopperRapidView, error) {
+
+	board = strings.TrimSpace(board)
+	if len(board) == 0 {
+		if len(rapidViews) == 1 {
+			return rapidViews[0], nil
+		}
+		return nil, errors.New(fmt.Sprintf(
+			"No board configured and %d boards found, configure the board by ID or name", len(rapidViews)))
+	}
+	for _, rapidView := range rapidViews {
+		if fmt.Sprint(rapidView.Id) == board || strings.EqualFold(rapidView.Name, board) {
+			return rapidView, nil
+		}
+	}
+	return nil, errors.New(fmt.Sprintf("Board '%s' not found in %d boards", board, len(rapidViews)))
+}
+
 // Get the Mavenlink tasks to be processed as JIRA sprints
 func (self *SprintFunctions) GetTasksToBeProcessed(subTasks []*mavenlinkCommunicator.Task, jiraSprints []*jiraCommunicator.Sprint,
 	toBeCreated bool) ([]*mavenlinkCommunicator.Task,
@@ -113,7 +167,7 @@ func (self *SprintFunctions) GetTasksToBeProcessed(subTasks []*mavenlinkCommunic
 	var tasks []*mavenlinkCommunicator.Task
 	sprints := map[string]*jiraCommunicator.Sprint{}
 	for _, task := range subTasks {
-		sprint := getMatchingSprintForTask(jiraSprints, task)
+		sprint := getMatchingSprintForTask(getRunContext(self.Context), jiraSprints, task)
 		if toBeCreated == true {
 			if sprint == nil {
 				tasks = append(tasks, task)
@@ -139,7 +193,8 @@ func (self *SprintFunctions) PrepareSprintsForCreation(sprintsAndTasks *POGO.Spr
 			true)
 		if toBeCreated != nil {
 			for _, toBe := range toBeCreated {
-				sprint := self.prepSprint(toBe, fmt.Sprint(sprintsAndTasks.GetRapidViews()[0].Id), 0)
+				sprint := self.prepSprint(toBe, fmt.Sprint(sprintsAndTasks.GetRapidView().Id), 0, "",
+					sprintsAndTasks.GetExternalProjectId())
 				if sprint != nil {
 					sprintsChannel <- *sprint
 				}
@@ -162,13 +217,16 @@ func (self *SprintFunctions) PrepareSprintsForUpdate(sprintsAndTasks *POGO.Sprin
 		if toBeSynced != nil {
 			for _, task := range toBeSynced {
 				relatedSprint := relatedSprints[task.Id]
-				if relatedSprint == nil {
+				if relatedSprint == nil || strings.EqualFold(relatedSprint.State, utility.SprintStateClosed) {
 					continue
 				}
 				if !strings.EqualFold(task.Title, relatedSprint.Name) ||
 					!strings.EqualFold(task.StartDate, self.cf.ParseJiraDateToMavenlinkDate(relatedSprint.StartDate)) ||
-					!strings.EqualFold(task.DueDate, self.cf.ParseJiraDateToMavenlinkDate(relatedSprint.EndDate)) {
-					sprint := self.prepSprint(task, fmt.Sprint(sprintsAndTasks.GetRapidViews()[0].Id), relatedSprint.Id)
+					!strings.EqualFold(task.DueDate, self.cf.ParseJiraDateToMavenlinkDate(relatedSprint.EndDate)) ||
+					!strings.EqualFold(relatedSprint.State, self.getSprintStateForTask(task, relatedSprint.State,
+						sprintsAndTasks.GetExternalProjectId())) {
+					sprint := self.prepSprint(task, fmt.Sprint(sprintsAndTasks.GetRapidView().Id), relatedSprint.Id,
+						relatedSprint.State, sprintsAndTasks.GetExternalProjectId())
 					if sprint != nil {
 						sprintsChannel <- *sprint
 					}
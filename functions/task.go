@@ -0,0 +1,60 @@
+package functions
+
+import (
+	"github.com/desertjinn/mavenlink-jira-sync/POGO"
+	synchronizer "github.com/desertjinn/mavenlink-jira-sync/proto/mavenlink-jira-sync"
+	"golang.org/x/net/context"
+	"strings"
+)
+
+type TaskFunctionsInterface interface {
+	PrepareTasksForUpdate(issuesAndTasks *POGO.IssueAndTask) (<-chan POGO.TaskWithMeta, <-chan bool)
+}
+
+type TaskFunctions struct {
+	cf CommonFunctions
+	// Context of the run the functions are bound to
+	Context context.Context
+}
+
+// Prepare linked JIRA issues' status & assignee as Mavenlink tasks for update purposes
+func (self *TaskFunctions) PrepareTasksForUpdate(issuesAndTasks *POGO.IssueAndTask) (
+	<-chan POGO.TaskWithMeta, <-chan bool) {
+
+	taskChannel := make(chan POGO.TaskWithMeta)
+	taskChannelClosed := make(chan bool)
+	go func() {
+		issue := IssueFunctions{Context: self.Context}
+		toBeSynced, relatedIssues := issue.GetTasksToBeProcessedAsIssues(issuesAndTasks.GetTasks(),
+			issuesAndTasks.GetIssues(), false)
+		for _, toBe := range toBeSynced {
+			existingIssue := relatedIssues[toBe.Id]
+			if existingIssue == nil || existingIssue.Fields == nil {
+				continue
+			}
+			task := POGO.TaskWithMeta{Task: toBe, ExistingIssueKey: existingIssue.Key}
+			if existingIssue.Fields.Status != nil &&
+				!self.cf.IsEquivalentToJira(existingIssue.Fields.Status.Name, strings.ToLower(toBe.State),
+					&synchronizer.EquivalenceTypes{Status: true,
+						ExternalProjectId: issuesAndTasks.GetExternalProjectId()}) {
+
+				task.State = self.cf.GetMavenlinkStatusFromJiraStatus(existingIssue.Fields.Status.Name,
+					issuesAndTasks.GetExternalProjectId())
+				if len(task.State) > 0 && !strings.EqualFold(task.State, toBe.State) {
+					task.StateChanged = true
+				}
+			}
+			assignee := getMavenlinkUserForJiraUser(existingIssue.Fields.Assignee, issuesAndTasks.GetMavenlinkUsers(),
+				issuesAndTasks.GetIdentityMapping())
+			if assignee != nil && (toBe.User == nil || assignee.Id != toBe.User.Id) {
+				task.Assignee = assignee
+				task.AssigneeChanged = true
+			}
+			if task.StateChanged || task.AssigneeChanged {
+				taskChannel <- task
+			}
+		}
+		taskChannelClosed <- true
+	}()
+	return taskChannel, taskChannelClosed
+}
@@ -0,0 +1,114 @@
+package functions
+
+import (
+	"fmt"
+	jiraCommunicator "github.com/desertjinn/jira-communicator/proto/jira-communicator"
+	"github.com/pkg/errors"
+	"sync"
+)
+
+type TransitionFunctionsInterface interface {
+	GetTransitionPath(transitions []*jiraCommunicator.Transition, fromStatus *jiraCommunicator.Status,
+		toStatus *jiraCommunicator.Status) ([]*jiraCommunicator.Transition, error)
+	LearnTransitions(workflowKey string, status *jiraCommunicator.Status,
+		available []*jiraCommunicator.Transition)
+	GetKnownTransitionPath(workflowKey string, fromStatus *jiraCommunicator.Status,
+		toStatus *jiraCommunicator.Status) ([]*jiraCommunicator.Transition, error)
+}
+
+type TransitionFunctions struct {
+	lock sync.Mutex
+	// Transitions JIRA offered issues, by workflow & the ID of the status they were offered from
+	learned map[string]map[string][]*jiraCommunicator.Transition
+}
+
+// Check if a JIRA workflow transition can be applied to an issue in the given status(global transitions have no source)
+func isTransitionAvailableFrom(transition *jiraCommunicator.Transition, statusId string) bool {
+	if len(transition.From) == 0 {
+		return true
+	}
+	for _, from := range transition.From {
+		if from != nil && from.Id == statusId {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the shortest chain of JIRA workflow transitions that takes an issue from one status to another
+func (self *TransitionFunctions) GetTransitionPath(transitions []*jiraCommunicator.Transition,
+	fromStatus *jiraCommunicator.Status, toStatus *jiraCommunicator.Status) ([]*jiraCommunicator.Transition, error) {
+
+	var path []*jiraCommunicator.Transition
+	if fromStatus.Id == toStatus.Id {
+		return path, nil
+	}
+	// Breadth first search over the workflow's statuses, remembering how each status was first reached
+	reachedBy := map[string]*jiraCommunicator.Transition{}
+	reachedFrom := map[string]string{fromStatus.Id: ""}
+	queue := []string{fromStatus.Id}
+	for len(queue) > 0 {
+		if _, reached := reachedBy[toStatus.Id]; reached {
+			break
+		}
+		current := queue[0]
+		queue = queue[1:]
+		for _, transition := range transitions {
+			if transition.To == nil || !isTransitionAvailableFrom(transition, current) {
+				continue
+			}
+			if _, visited := reachedFrom[transition.To.Id]; visited {
+				continue
+			}
+			reachedBy[transition.To.Id] = transition
+			reachedFrom[transition.To.Id] = current
+			queue = append(queue, transition.To.Id)
+		}
+	}
+	if _, reached := reachedBy[toStatus.Id]; !reached {
+		return path, errors.New(fmt.Sprintf("No workflow path from status '%s' to '%s'", fromStatus.Name,
+			toStatus.Name))
+	}
+	for statusId := toStatus.Id; statusId != fromStatus.Id; statusId = reachedFrom[statusId] {
+		path = append([]*jiraCommunicator.Transition{reachedBy[statusId]}, path...)
+	}
+	return path, nil
+}
+
+// Record the transitions JIRA offers an issue of the workflow in the given status, replacing the ones offered before
+func (self *TransitionFunctions) LearnTransitions(workflowKey string, status *jiraCommunicator.Status,
+	available []*jiraCommunicator.Transition) {
+
+	var offered []*jiraCommunicator.Transition
+	for _, transition := range available {
+		if transition == nil || transition.To == nil {
+			continue
+		}
+		// JIRA lists the transitions available from an issue's current status, seldom saying where they're from
+		offered = append(offered, &jiraCommunicator.Transition{Id: transition.Id, Name: transition.Name,
+			To: transition.To, From: []*jiraCommunicator.Status{status}})
+	}
+	self.lock.Lock()
+	defer self.lock.Unlock()
+	if self.learned == nil {
+		self.learned = map[string]map[string][]*jiraCommunicator.Transition{}
+	}
+	if self.learned[workflowKey] == nil {
+		self.learned[workflowKey] = map[string][]*jiraCommunicator.Transition{}
+	}
+	self.learned[workflowKey][status.Id] = offered
+}
+
+// Get the shortest chain of transitions of the workflow known to take an issue from one status to another, every
+// one of them having been offered by JIRA from the status it leaves
+func (self *TransitionFunctions) GetKnownTransitionPath(workflowKey string, fromStatus *jiraCommunicator.Status,
+	toStatus *jiraCommunicator.Status) ([]*jiraCommunicator.Transition, error) {
+
+	var known []*jiraCommunicator.Transition
+	self.lock.Lock()
+	for _, offered := range self.learned[workflowKey] {
+		known = append(known, offered...)
+	}
+	self.lock.Unlock()
+	return self.GetTransitionPath(known, fromStatus, toStatus)
+}
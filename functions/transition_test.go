@@ -0,0 +1,113 @@
+package functions
+
+import (
+	jiraCommunicator "github.com/desertjinn/jira-communicator/proto/jira-communicator"
+	"testing"
+)
+
+var (
+	statusOpen       = &jiraCommunicator.Status{Id: "1", Name: "Open"}
+	statusInProgress = &jiraCommunicator.Status{Id: "3", Name: "In Progress"}
+	statusReview     = &jiraCommunicator.Status{Id: "4", Name: "Review"}
+	statusClosed     = &jiraCommunicator.Status{Id: "6", Name: "Closed"}
+)
+
+func transition(id string, to *jiraCommunicator.Status, from ...*jiraCommunicator.Status) *jiraCommunicator.Transition {
+	return &jiraCommunicator.Transition{Id: id, Name: "To " + to.Name, To: to, From: from}
+}
+
+func transitionIds(path []*jiraCommunicator.Transition) []string {
+	var ids []string
+	for _, step := range path {
+		ids = append(ids, step.Id)
+	}
+	return ids
+}
+
+func assertPath(t *testing.T, path []*jiraCommunicator.Transition, err error, expected ...string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("expected path %v, got error: %v", expected, err)
+	}
+	ids := transitionIds(path)
+	if len(ids) != len(expected) {
+		t.Fatalf("expected path %v, got %v", expected, ids)
+	}
+	for index := range ids {
+		if ids[index] != expected[index] {
+			t.Fatalf("expected path %v, got %v", expected, ids)
+		}
+	}
+}
+
+func TestGetTransitionPath(t *testing.T) {
+	transitions := []*jiraCommunicator.Transition{
+		transition("11", statusInProgress, statusOpen),
+		transition("21", statusReview, statusInProgress),
+		transition("31", statusClosed, statusReview),
+		transition("41", statusClosed, statusInProgress),
+	}
+	functions := new(TransitionFunctions)
+
+	path, err := functions.GetTransitionPath(transitions, statusOpen, statusClosed)
+	assertPath(t, path, err, "11", "41")
+
+	path, err = functions.GetTransitionPath(transitions, statusOpen, statusOpen)
+	assertPath(t, path, err)
+
+	if _, err = functions.GetTransitionPath(transitions, statusClosed, statusOpen); err == nil {
+		t.Fatal("expected no path from 'Closed' to 'Open'")
+	}
+}
+
+func TestGetTransitionPathWithGlobalTransition(t *testing.T) {
+	transitions := []*jiraCommunicator.Transition{
+		transition("11", statusInProgress, statusOpen),
+		transition("91", statusOpen),
+	}
+	path, err := new(TransitionFunctions).GetTransitionPath(transitions, statusInProgress, statusOpen)
+	assertPath(t, path, err, "91")
+}
+
+func TestGetKnownTransitionPathOnlyFollowsLearnedStatuses(t *testing.T) {
+	functions := new(TransitionFunctions)
+	functions.LearnTransitions("PROJ/1", statusOpen, []*jiraCommunicator.Transition{
+		transition("11", statusInProgress),
+	})
+
+	// Nothing was seen offered from 'In Progress' yet, so 'Closed' can't be reached
+	if _, err := functions.GetKnownTransitionPath("PROJ/1", statusOpen, statusClosed); err == nil {
+		t.Fatal("expected no known path through a status whose transitions weren't learned")
+	}
+
+	functions.LearnTransitions("PROJ/1", statusInProgress, []*jiraCommunicator.Transition{
+		transition("21", statusReview),
+		transition("41", statusClosed),
+	})
+	path, err := functions.GetKnownTransitionPath("PROJ/1", statusOpen, statusClosed)
+	assertPath(t, path, err, "11", "41")
+
+	// Transitions learned from one status aren't taken for ones available from every status
+	if _, err = functions.GetKnownTransitionPath("PROJ/1", statusReview, statusClosed); err == nil {
+		t.Fatal("expected no known path from 'Review'")
+	}
+
+	// Another workflow learns nothing from this one
+	if _, err = functions.GetKnownTransitionPath("PROJ/2", statusOpen, statusInProgress); err == nil {
+		t.Fatal("expected no known path in another workflow")
+	}
+}
+
+func TestLearnTransitionsReplacesTheOnesOfferedBefore(t *testing.T) {
+	functions := new(TransitionFunctions)
+	functions.LearnTransitions("PROJ/1", statusOpen, []*jiraCommunicator.Transition{
+		transition("11", statusInProgress),
+		transition("51", statusClosed),
+	})
+	functions.LearnTransitions("PROJ/1", statusOpen, []*jiraCommunicator.Transition{
+		transition("11", statusInProgress),
+	})
+	if _, err := functions.GetKnownTransitionPath("PROJ/1", statusOpen, statusClosed); err == nil {
+		t.Fatal("expected the transition no longer offered to be forgotten")
+	}
+}
@@ -7,9 +7,11 @@ import (
 	datasourceCommunicator "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
 	"github.com/desertjinn/mavenlink-jira-sync/POGO"
 	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"golang.org/x/net/context"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type WorklogFunctionsInterface interface {
@@ -20,18 +22,50 @@ type WorklogFunctionsInterface interface {
 		chan jiraCommunicator.WorklogWithMeta, chan bool)
 	PrepareWorklogsForUpdate(issuesAndTasks *POGO.IssueAndTask) (
 		chan jiraCommunicator.WorklogWithMeta, chan bool)
+	PrepareWorklogsForMove(issuesAndTasks *POGO.IssueAndTask) (
+		chan POGO.MovedWorklog, chan bool)
+	GetRemovedTimeEntries(syncedWorklogs []*datasourceCommunicator.ExternalTimeEntries,
+		allTimeEntries []*mavenlinkCommunicator.Timeentry) []*datasourceCommunicator.ExternalTimeEntries
+	GetWorklogsToBeProcessedAsTimeEntries(allWorklogs []*jiraCommunicator.Worklog) []*jiraCommunicator.Worklog
+	PrepareTimeEntriesForCreation(issuesAndTasks *POGO.IssueAndTask) (
+		chan POGO.TimeentryWithMeta, chan bool)
 }
 
+// Attribution the comment of a worklog logged by the fallback author ends with
+var fallbackAttributionPattern = regexp.MustCompile(`\[Logged for .* from Mavenlink time entry \d+\]`)
+
 type WorklogFunctions struct {
 	cf CommonFunctions
+	// Context of the run the functions are bound to
+	Context context.Context
 }
 
 // Check if a Mavenlink time entry exists in the datasource
-func doesTimeEntryExistInDataSource(timeentry int32) *datasourceCommunicator.ExternalTimeEntries {
+func doesTimeEntryExistInDataSource(ctx context.Context,
+	timeentry int32) *datasourceCommunicator.ExternalTimeEntries {
+
 	externalTimeEntry := &datasourceCommunicator.ExternalTimeEntries{}
 	externalTimeEntry.Source2LogId = timeentry
 	worklogAndTimeEntryResponse, worklogAndTimeentryResponseErr :=
-		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTimeentry(utility.GetUtilitiesSingleton().CommsContext,
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTimeentry(ctx,
+			externalTimeEntry)
+	if worklogAndTimeentryResponseErr == nil && worklogAndTimeEntryResponse.Error == nil &&
+		worklogAndTimeEntryResponse.Timeentry != nil {
+		if worklogAndTimeEntryResponse.Timeentry.Id != 0 {
+			return worklogAndTimeEntryResponse.Timeentry
+		}
+	}
+	return nil
+}
+
+// Check if a JIRA worklog exists in the datasource
+func doesWorklogExistInDataSource(ctx context.Context,
+	worklog int32) *datasourceCommunicator.ExternalTimeEntries {
+
+	externalTimeEntry := &datasourceCommunicator.ExternalTimeEntries{}
+	externalTimeEntry.Source1LogId = worklog
+	worklogAndTimeEntryResponse, worklogAndTimeentryResponseErr :=
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetWorklog(ctx,
 			externalTimeEntry)
 	if worklogAndTimeentryResponseErr == nil && worklogAndTimeEntryResponse.Error == nil &&
 		worklogAndTimeEntryResponse.Timeentry != nil {
@@ -42,14 +76,29 @@ func doesTimeEntryExistInDataSource(timeentry int32) *datasourceCommunicator.Ext
 	return nil
 }
 
+// Check if a JIRA issue that is linked to a Mavenlink task in a sub-task exists in the datasource
+func doesIssueExistInDatasource(ctx context.Context, issue int32) *datasourceCommunicator.ExternalTasks {
+	taskAndIssue := &datasourceCommunicator.ExternalTasks{}
+	taskAndIssue.Source1TaskId = issue
+	taskAndIssueResponse, taskAndIssueResponseErr :=
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTaskInSubTaskFromIssueId(
+			ctx, taskAndIssue)
+	if taskAndIssueResponseErr == nil && taskAndIssueResponse.Error == nil && taskAndIssueResponse.Task != nil {
+		if taskAndIssueResponse.Task.Id != 0 {
+			return taskAndIssueResponse.Task
+		}
+	}
+	return nil
+}
+
 // Check if JIRA worklog and Mavenlink time entry combination exists in the data source
-func doesWorklogAndTimeEntryExistInDataSource(timeentry int32, worklog int32) bool {
+func doesWorklogAndTimeEntryExistInDataSource(ctx context.Context, timeentry int32, worklog int32) bool {
 	var does bool
 	worklogAndTimeentry := &datasourceCommunicator.ExternalTimeEntries{}
 	worklogAndTimeentry.Source1LogId = worklog
 	worklogAndTimeentry.Source2LogId = timeentry
 	worklogAndTimeentryResponse, worklogAndTimeentryResponseErr :=
-		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTimeentryAndWorklog(utility.GetUtilitiesSingleton().CommsContext,
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTimeentryAndWorklog(ctx,
 			worklogAndTimeentry)
 	if worklogAndTimeentryResponseErr == nil && worklogAndTimeentryResponse.Error == nil &&
 		worklogAndTimeentryResponse.Timeentry != nil {
@@ -62,7 +111,7 @@ func doesWorklogAndTimeEntryExistInDataSource(timeentry int32, worklog int32) bo
 }
 
 // Get the JIRA worklog related to the Mavenlink time entry
-func getMatchingWorklogForTimeEntry(worklogs []*jiraCommunicator.Worklog,
+func getMatchingWorklogForTimeEntry(ctx context.Context, worklogs []*jiraCommunicator.Worklog,
 	timeEntry *mavenlinkCommunicator.Timeentry) *jiraCommunicator.Worklog {
 
 	var timeentryId int32
@@ -71,7 +120,7 @@ func getMatchingWorklogForTimeEntry(worklogs []*jiraCommunicator.Worklog,
 		return nil
 	}
 	timeentryId = int32(timeentryId64)
-	timeentryInDb := doesTimeEntryExistInDataSource(timeentryId)
+	timeentryInDb := doesTimeEntryExistInDataSource(ctx, timeentryId)
 	if nil != timeentryInDb {
 		for _, worklog := range worklogs {
 			var worklogId int32
@@ -81,7 +130,7 @@ func getMatchingWorklogForTimeEntry(worklogs []*jiraCommunicator.Worklog,
 			}
 			worklogId = int32(issueId64)
 			if worklogId == timeentryInDb.Source1LogId {
-				exists := doesWorklogAndTimeEntryExistInDataSource(timeentryId, worklogId)
+				exists := doesWorklogAndTimeEntryExistInDataSource(ctx, timeentryId, worklogId)
 				if exists == true {
 					return worklog
 				}
@@ -91,8 +140,105 @@ func getMatchingWorklogForTimeEntry(worklogs []*jiraCommunicator.Worklog,
 	return nil
 }
 
-func prepWorklog(timeEntry *mavenlinkCommunicator.Timeentry, timezone string,
-	worklogId string) *jiraCommunicator.WorklogWithMeta {
+// Check if the Mavenlink time entry now belongs to a task linked to a different JIRA issue than its worklog
+func isTimeEntryMoved(ctx context.Context, timeEntry *mavenlinkCommunicator.Timeentry,
+	worklog *jiraCommunicator.Worklog) bool {
+
+	taskId64, taskIdErr := strconv.ParseInt(timeEntry.StoryId, 10, 32)
+	if taskIdErr != nil || len(worklog.IssueId) == 0 {
+		return false
+	}
+	taskInDb := doesTaskInSubTaskExistInDatasource(ctx, int32(taskId64))
+	if taskInDb == nil || taskInDb.Source1TaskId == 0 {
+		return false
+	}
+	return fmt.Sprint(taskInDb.Source1TaskId) != worklog.IssueId
+}
+
+// Check if the JIRA worklog was logged by the fallback author while its Mavenlink user now has a JIRA account
+func isWorklogReassignable(ctx context.Context, timeEntry *mavenlinkCommunicator.Timeentry,
+	users []*jiraCommunicator.Author, identities *POGO.IdentityMapping) bool {
+
+	timeentryId64, timeentryIdErr := strconv.ParseInt(timeEntry.Id, 10, 32)
+	if timeentryIdErr != nil {
+		return false
+	}
+	timeentryInDb := doesTimeEntryExistInDataSource(ctx, int32(timeentryId64))
+	if timeentryInDb == nil || timeentryInDb.FallbackFlag == 0 {
+		return false
+	}
+	return getJiraUserForMavenlinkUser(timeEntry.User, users, identities) != nil
+}
+
+// Get the Mavenlink time entry logging the same time as another, on the same task & day by the same user
+func getEquivalentTimeEntry(timeEntries []*mavenlinkCommunicator.Timeentry,
+	timeEntry *mavenlinkCommunicator.Timeentry) *mavenlinkCommunicator.Timeentry {
+
+	for _, existing := range timeEntries {
+		if existing.StoryId == timeEntry.StoryId && existing.DatePerformed == timeEntry.DatePerformed &&
+			existing.TimeInMinutes == timeEntry.TimeInMinutes && existing.User != nil && timeEntry.User != nil &&
+			existing.User.Id == timeEntry.User.Id {
+
+			return existing
+		}
+	}
+	return nil
+}
+
+// Get the JIRA worklog comment for a Mavenlink time entry logged by the fallback author
+func getFallbackWorklogComment(timeEntry *mavenlinkCommunicator.Timeentry) string {
+	attribution := fmt.Sprintf("[Logged for %s (%s) from Mavenlink time entry %s]", timeEntry.User.FullName,
+		timeEntry.User.EmailAddress, timeEntry.Id)
+	if len(strings.TrimSpace(timeEntry.Notes)) == 0 {
+		return attribution
+	}
+	return fmt.Sprintf("%s\n\n%s", timeEntry.Notes, attribution)
+}
+
+// Load a timezone by its IANA name, ignoring unknown names
+func loadLocation(timezone string) *time.Location {
+	if len(timezone) == 0 {
+		return nil
+	}
+	location, locationErr := time.LoadLocation(timezone)
+	if locationErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+			fmt.Sprintf("Ignoring unknown timezone '%s'", timezone))
+		return nil
+	}
+	return location
+}
+
+// Get the timezone a Mavenlink time entry was performed in, from its JIRA user, then the project & then the offset the
+// time entry was created with
+func getTimeEntryLocation(timeEntry *mavenlinkCommunicator.Timeentry, author *jiraCommunicator.Author,
+	projectTimezone string) *time.Location {
+
+	if author != nil {
+		if location := loadLocation(author.TimeZone); location != nil {
+			return location
+		}
+	}
+	if location := loadLocation(projectTimezone); location != nil {
+		return location
+	}
+	if createdAt, createdAtErr := time.Parse(time.RFC3339, timeEntry.CreatedAt); createdAtErr == nil {
+		return createdAt.Location()
+	}
+	return utility.DefaultWorklogLocation
+}
+
+// Get the calendar day of a JIRA worklog's start time in a timezone
+func getWorklogDateInLocation(started string, location *time.Location) string {
+	startedAt, startedAtErr := time.Parse(utility.JiraDateTimeFormat, started)
+	if startedAtErr != nil {
+		return ""
+	}
+	return startedAt.In(location).Format(utility.MavenlinkDateFormat)
+}
+
+func prepWorklog(timeEntry *mavenlinkCommunicator.Timeentry, users []*jiraCommunicator.Author,
+	identities *POGO.IdentityMapping, projectTimezone string, worklogId string) *jiraCommunicator.WorklogWithMeta {
 
 	worklog := new(jiraCommunicator.WorklogWithMeta)
 	if len(worklogId) > 0 {
@@ -100,23 +246,71 @@ func prepWorklog(timeEntry *mavenlinkCommunicator.Timeentry, timezone string,
 	}
 	worklog.TimeSpentSeconds = int64(timeEntry.TimeInMinutes * 60)
 	worklog.Comment = timeEntry.Notes
-	if len(timezone) <= 0 {
-		timezone = "+0530"
-	}
-	worklog.Started = fmt.Sprintf("%sT%s%s", timeEntry.DatePerformed, "06:00:00.000", timezone)
 	worklog.Created = timeEntry.CreatedAt
 	worklog.Updated = timeEntry.UpdatedAt
 	worklog.Author = new(jiraCommunicator.Author)
 	worklog.Author.EmailAddress = timeEntry.User.EmailAddress
+	author := getJiraUserForMavenlinkUser(timeEntry.User, users, identities)
+	if author == nil {
+		if author = identities.GetFallbackAuthor(); author != nil {
+			worklog.Comment = getFallbackWorklogComment(timeEntry)
+			worklog.FallbackAuthor = true
+		}
+	}
+	location := getTimeEntryLocation(timeEntry, nil, projectTimezone)
+	if !worklog.FallbackAuthor {
+		location = getTimeEntryLocation(timeEntry, author, projectTimezone)
+	}
+	performed, performedErr := time.ParseInLocation(utility.MavenlinkDateFormat, timeEntry.DatePerformed, location)
+	if performedErr == nil {
+		worklog.Started = performed.Add(time.Hour * utility.WorklogStartHour).Format(utility.JiraDateTimeFormat)
+	}
+	if author != nil {
+		worklog.Author.Name = author.Name
+		worklog.Author.AccountId = author.AccountId
+		if len(author.EmailAddress) > 0 {
+			worklog.Author.EmailAddress = author.EmailAddress
+		}
+	}
 	worklog.UpdateAuthor = new(jiraCommunicator.Author)
-	worklog.UpdateAuthor.EmailAddress = timeEntry.User.EmailAddress
+	worklog.UpdateAuthor.EmailAddress = worklog.Author.EmailAddress
+	worklog.UpdateAuthor.Name = worklog.Author.Name
+	worklog.UpdateAuthor.AccountId = worklog.Author.AccountId
 	worklog.MavenlinkTaskInSubTaskId = timeEntry.StoryId
 	worklog.MavenlinkTimeentryId = timeEntry.Id
 	worklog.MavenlinkTimeentryUserId = timeEntry.User.Id
+	worklog.MavenlinkTimeentryUserEmail = timeEntry.User.EmailAddress
 
 	return worklog
 }
 
+func prepTimeentry(worklog *jiraCommunicator.Worklog, taskId int32,
+	user *mavenlinkCommunicator.User) *POGO.TimeentryWithMeta {
+
+	pat := regexp.MustCompile(`(.*?)T(.*)`)
+	startedDateMatch := pat.FindStringSubmatch(worklog.Started)
+	if len(startedDateMatch) < 2 || len(startedDateMatch[1]) == 0 {
+		return nil
+	}
+	performedDate := startedDateMatch[1]
+	if location := loadLocation(worklog.Author.TimeZone); location != nil {
+		if inLocation := getWorklogDateInLocation(worklog.Started, location); len(inLocation) > 0 {
+			performedDate = inLocation
+		}
+	}
+	timeentry := new(POGO.TimeentryWithMeta)
+	timeentry.Timeentry.StoryId = fmt.Sprint(taskId)
+	timeentry.Timeentry.DatePerformed = performedDate
+	timeentry.Timeentry.TimeInMinutes = int32(worklog.TimeSpentSeconds / 60)
+	timeentry.Timeentry.Notes = worklog.Comment
+	timeentry.Timeentry.User = user
+	timeentry.JiraIssueId = worklog.IssueId
+	timeentry.JiraWorklogId = worklog.Id
+	timeentry.JiraUserEmail = worklog.Author.EmailAddress
+
+	return timeentry
+}
+
 // Get the Mavenlink tasks to be processed as JIRA issues
 func (self *WorklogFunctions) GetTimeEntriesToBeProcessedAsWorklogs(allTimeEntries []*mavenlinkCommunicator.Timeentry,
 	jiraWorklogs []*jiraCommunicator.Worklog, toBeCreated bool) ([]*mavenlinkCommunicator.Timeentry,
@@ -125,7 +319,7 @@ func (self *WorklogFunctions) GetTimeEntriesToBeProcessedAsWorklogs(allTimeEntri
 	var timeEntries []*mavenlinkCommunicator.Timeentry
 	worklogs := map[string]*jiraCommunicator.Worklog{}
 	for _, timeEntry := range allTimeEntries {
-		worklog := getMatchingWorklogForTimeEntry(jiraWorklogs, timeEntry)
+		worklog := getMatchingWorklogForTimeEntry(getRunContext(self.Context), jiraWorklogs, timeEntry)
 		if toBeCreated == true {
 			if worklog == nil {
 				timeEntries = append(timeEntries, timeEntry)
@@ -147,12 +341,11 @@ func (self *WorklogFunctions) PrepareWorklogsForCreation(issuesAndTasks *POGO.Is
 	worklogsChannel := make(chan jiraCommunicator.WorklogWithMeta)
 	worklogsChannelClosed := make(chan bool)
 	go func() {
-		var timezone string
 		toBeCreated, _ := self.GetTimeEntriesToBeProcessedAsWorklogs(issuesAndTasks.GetTimeentries(),
 			issuesAndTasks.GetWorklogs(), true)
-		timezone = "+0530"
 		for _, toBe := range toBeCreated {
-			preppedWorklog := prepWorklog(toBe, timezone, "")
+			preppedWorklog := prepWorklog(toBe, issuesAndTasks.GetUsers(), issuesAndTasks.GetIdentityMapping(),
+				issuesAndTasks.GetTimezone(), "")
 			worklogsChannel <- *preppedWorklog
 		}
 		worklogsChannelClosed <- true
@@ -169,37 +362,147 @@ func (self *WorklogFunctions) PrepareWorklogsForUpdate(issuesAndTasks *POGO.Issu
 	go func() {
 		toBeSynced, relatedWorklogs := self.GetTimeEntriesToBeProcessedAsWorklogs(issuesAndTasks.GetTimeentries(),
 			issuesAndTasks.GetWorklogs(), false)
+		ctx := getRunContext(self.Context)
 		for _, toBe := range toBeSynced {
-			var startedDate string
-			var timezone string
 			existingWorklog := relatedWorklogs[toBe.Id]
-			timezone = "+0530"
-			//patForTimezone := regexp.MustCompile(`.*?T[0-9]+:[0-9]+:[0-9]+(.*)`)
-			//timezoneMatch := patForTimezone.FindStringSubmatch(toBe.CreatedAt)
-			//if 0 < len(timezoneMatch) && 0 < len(timezoneMatch[1]) {
-			//	var replacer = strings.NewReplacer(":", "")
-			//	timezone = replacer.Replace(timezoneMatch[1])
-			//} else {
-			//	timezone = "+0530"
-			//}
-			pat := regexp.MustCompile(`(.*?)T(.*)`)
-			startedDateMatch := pat.FindStringSubmatch(existingWorklog.Started)
-			if 0 < len(startedDateMatch[1]) {
-				startedDate = startedDateMatch[1]
-				if existingWorklog.TimeSpentSeconds != int64(toBe.TimeInMinutes*60) ||
-					!strings.EqualFold(startedDate, toBe.DatePerformed) ||
-					!strings.EqualFold(existingWorklog.Comment, toBe.Notes) {
-
-					preppedWorklog := prepWorklog(toBe, timezone, existingWorklog.Id)
-					worklogsChannel <- *preppedWorklog
-				}
-			} else {
+			if isTimeEntryMoved(ctx, toBe, existingWorklog) ||
+				isWorklogReassignable(ctx, toBe, issuesAndTasks.GetUsers(), issuesAndTasks.GetIdentityMapping()) {
+				continue
+			}
+			preppedWorklog := prepWorklog(toBe, issuesAndTasks.GetUsers(), issuesAndTasks.GetIdentityMapping(),
+				issuesAndTasks.GetTimezone(), existingWorklog.Id)
+			// Compare the calendar day the worklog starts on in the timezone the time entry was performed in
+			preppedStarted, preppedStartedErr := time.Parse(utility.JiraDateTimeFormat, preppedWorklog.Started)
+			startedDate := getWorklogDateInLocation(existingWorklog.Started, preppedStarted.Location())
+			if preppedStartedErr != nil || len(startedDate) == 0 {
 				utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
-					"Failed to find existing worklog date to simple date format(2006-01-02)")
+					fmt.Sprintf("Failed to find existing worklog date to simple date format(%s)",
+						utility.MavenlinkDateFormat))
 				continue
 			}
+			if existingWorklog.TimeSpentSeconds != preppedWorklog.TimeSpentSeconds ||
+				!strings.EqualFold(startedDate, toBe.DatePerformed) ||
+				!strings.EqualFold(existingWorklog.Comment, preppedWorklog.Comment) {
+
+				worklogsChannel <- *preppedWorklog
+			}
+		}
+		worklogsChannelClosed <- true
+	}()
+	return worklogsChannel, worklogsChannelClosed
+}
+
+// Prepare Mavenlink sub-task time entries moved to another task, or whose user now has a JIRA account, as JIRA issue
+// worklogs to be recreated
+func (self *WorklogFunctions) PrepareWorklogsForMove(issuesAndTasks *POGO.IssueAndTask) (
+	chan POGO.MovedWorklog, chan bool) {
+
+	worklogsChannel := make(chan POGO.MovedWorklog)
+	worklogsChannelClosed := make(chan bool)
+	go func() {
+		toBeSynced, relatedWorklogs := self.GetTimeEntriesToBeProcessedAsWorklogs(issuesAndTasks.GetTimeentries(),
+			issuesAndTasks.GetWorklogs(), false)
+		ctx := getRunContext(self.Context)
+		for _, toBe := range toBeSynced {
+			existingWorklog := relatedWorklogs[toBe.Id]
+			reassigned := false
+			if !isTimeEntryMoved(ctx, toBe, existingWorklog) {
+				if !isWorklogReassignable(ctx, toBe, issuesAndTasks.GetUsers(), issuesAndTasks.GetIdentityMapping()) {
+					continue
+				}
+				reassigned = true
+			}
+			preppedWorklog := prepWorklog(toBe, issuesAndTasks.GetUsers(), issuesAndTasks.GetIdentityMapping(),
+				issuesAndTasks.GetTimezone(), existingWorklog.Id)
+			worklogsChannel <- POGO.MovedWorklog{Worklog: *preppedWorklog, PreviousIssueId: existingWorklog.IssueId,
+				Reassigned: reassigned}
 		}
 		worklogsChannelClosed <- true
 	}()
 	return worklogsChannel, worklogsChannelClosed
 }
+
+// Get the synced JIRA worklogs whose Mavenlink time entries no longer exist
+func (self *WorklogFunctions) GetRemovedTimeEntries(syncedWorklogs []*datasourceCommunicator.ExternalTimeEntries,
+	allTimeEntries []*mavenlinkCommunicator.Timeentry) []*datasourceCommunicator.ExternalTimeEntries {
+
+	var removedTimeEntries []*datasourceCommunicator.ExternalTimeEntries
+	existingTimeEntries := map[int32]bool{}
+	for _, timeEntry := range allTimeEntries {
+		existingTimeEntries[self.cf.GetIdFromString(timeEntry.Id)] = true
+	}
+	for _, syncedWorklog := range syncedWorklogs {
+		if _, exists := existingTimeEntries[syncedWorklog.Source2LogId]; !exists {
+			removedTimeEntries = append(removedTimeEntries, syncedWorklog)
+		}
+	}
+	return removedTimeEntries
+}
+
+// Get the JIRA worklogs that haven't been synced with Mavenlink time entries
+func (self *WorklogFunctions) GetWorklogsToBeProcessedAsTimeEntries(
+	allWorklogs []*jiraCommunicator.Worklog) []*jiraCommunicator.Worklog {
+
+	var worklogs []*jiraCommunicator.Worklog
+	for _, worklog := range allWorklogs {
+		worklogId := self.cf.GetIdFromString(worklog.Id)
+		if worklogId == 0 {
+			continue
+		}
+		if doesWorklogExistInDataSource(getRunContext(self.Context), worklogId) == nil {
+			worklogs = append(worklogs, worklog)
+		}
+	}
+	return worklogs
+}
+
+// Prepare JIRA issue worklogs as Mavenlink sub-task time entries for creation purposes
+func (self *WorklogFunctions) PrepareTimeEntriesForCreation(issuesAndTasks *POGO.IssueAndTask) (
+	chan POGO.TimeentryWithMeta, chan bool) {
+
+	timeentriesChannel := make(chan POGO.TimeentryWithMeta)
+	timeentriesChannelClosed := make(chan bool)
+	go func() {
+		toBeCreated := self.GetWorklogsToBeProcessedAsTimeEntries(issuesAndTasks.GetWorklogs())
+		for _, toBe := range toBeCreated {
+			if toBe.Author == nil {
+				continue
+			}
+			taskInDb := doesIssueExistInDatasource(getRunContext(self.Context), self.cf.GetIdFromString(toBe.IssueId))
+			if taskInDb == nil {
+				continue
+			}
+			// Worklogs the sync logged itself, whose history failed to save or whose delete failed after a move,
+			// would bill their time entries twice
+			if issuesAndTasks.GetIdentityMapping().IsSyncAuthor(toBe.Author) ||
+				fallbackAttributionPattern.MatchString(toBe.Comment) {
+
+				utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+					fmt.Sprintf("Ignoring worklog %s of issue %s, it was logged by the sync", toBe.Id, toBe.IssueId))
+				continue
+			}
+			user := getMavenlinkUserForJiraUser(toBe.Author, issuesAndTasks.GetMavenlinkUsers(),
+				issuesAndTasks.GetIdentityMapping())
+			if user == nil {
+				utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+					fmt.Sprintf("Failed to find Mavenlink user for worklog %s by '%s'", toBe.Id,
+						toBe.Author.EmailAddress))
+				continue
+			}
+			preppedTimeentry := prepTimeentry(toBe, taskInDb.Source2TaskId, user)
+			if preppedTimeentry == nil {
+				continue
+			}
+			existing := getEquivalentTimeEntry(issuesAndTasks.GetTimeentries(), &preppedTimeentry.Timeentry)
+			if existing != nil {
+				utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+					fmt.Sprintf("Ignoring worklog %s of issue %s, Mavenlink time entry %s already logs it", toBe.Id,
+						toBe.IssueId, existing.Id))
+				continue
+			}
+			timeentriesChannel <- *preppedTimeentry
+		}
+		timeentriesChannelClosed <- true
+	}()
+	return timeentriesChannel, timeentriesChannelClosed
+}
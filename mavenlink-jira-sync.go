@@ -8,10 +8,23 @@ import (
 	"github.com/desertjinn/mavenlink-jira-sync/utility"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/micro/go-micro/cmd"
+	"os"
+	"strings"
 	"sync"
 )
 
+// Take the subcommand & its arguments out of the command line, leaving the rest to go-micro
+func getSubcommand() (string, []string) {
+	if len(os.Args) < 2 || strings.HasPrefix(os.Args[1], "-") {
+		return "", nil
+	}
+	subcommand, args := os.Args[1], os.Args[2:]
+	os.Args = os.Args[:1]
+	return subcommand, args
+}
+
 func main() {
+	subcommand, subcommandArgs := getSubcommand()
 	cmd.Init()
 
 	var env synchronizer.EnvironmentConfiguration
@@ -29,13 +42,43 @@ func main() {
 	dataSourceService := new(services.DataSourceService)
 	commonFunctions := new(functions.CommonFunctions)
 	syncOperations := SyncOperations{
-		common:     commonFunctions,
-		sprint:     new(functions.SprintFunctions),
-		issue:      new(functions.IssueFunctions),
-		worklog:    new(functions.WorklogFunctions),
-		datasource: dataSourceService,
-		jira:       new(services.JiraService),
-		mavenlink:  new(services.MavenlinkService),
+		environment: &env,
+		common:      commonFunctions,
+		sprint:      new(functions.SprintFunctions),
+		epic:        new(functions.EpicFunctions),
+		issue:       new(functions.IssueFunctions),
+		worklog:     new(functions.WorklogFunctions),
+		task:        new(functions.TaskFunctions),
+		comment:     new(functions.CommentFunctions),
+		attachment:  new(functions.AttachmentFunctions),
+		transition:  new(functions.TransitionFunctions),
+		identity:    new(functions.IdentityFunctions),
+		datasource:  dataSourceService,
+		jira:        new(services.JiraService),
+		mavenlink:   new(services.MavenlinkService),
+	}
+
+	// Serving retries JIRA's metadata itself, the other modes having nothing to sync without it
+	if subcommand != ServeSubcommand {
+		if metadataErr := utility.GetUtilitiesSingleton().GetJiraMetadataError(); metadataErr != nil {
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+				fmt.Sprintf("Failed to retrieve JIRA's metadata: %v", metadataErr))
+			os.Exit(1)
+		}
+	}
+	switch subcommand {
+	case "":
+	case OnboardSubcommand:
+		runOnboarding(&syncOperations, subcommandArgs)
+		return
+	case ServeSubcommand:
+		runServer(&syncOperations, subcommandArgs)
+		return
+	default:
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+			fmt.Sprintf("Unknown subcommand '%s', expected '%s' or '%s'", subcommand, OnboardSubcommand,
+				ServeSubcommand))
+		os.Exit(2)
 	}
 
 	wg.Add(1)
@@ -53,6 +96,10 @@ func main() {
 
 	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.TriangularBulletPoint,
 		"Syncing Mavenlink →→ JIRA")
+	if syncOperations.isJiraMaster() {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.TriangularBulletPoint,
+			"Syncing JIRA →→ Mavenlink(status & assignee)")
+	}
 	success := make(chan bool)
 	var startedSyncing int
 	for syncConfigurationKey, syncConfiguration := range syncConfigurations {
@@ -60,7 +107,13 @@ func main() {
 			utility.TriangularBulletPoint+utility.TriangularBulletPoint,
 			fmt.Sprintf("Processing configuration No.%d: %s",
 				syncConfigurationKey+1, syncConfiguration.ProjectName))
-		validConfiguration := syncOperations.IsAValidSyncConfiguration(syncConfiguration)
+		provisionErr := syncOperations.ProvisionEpicIfRequired(syncConfiguration)
+		if provisionErr != nil {
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+				fmt.Sprintf("Failed to provision the JIRA epic for '%s': %v", syncConfiguration.ProjectName,
+					provisionErr))
+		}
+		validConfiguration := provisionErr == nil && syncOperations.IsAValidSyncConfiguration(syncConfiguration)
 		if validConfiguration == true {
 			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Check, fmt.Sprintf(
 				"Configuration for '%s' is valid", syncConfiguration.ProjectName))
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	jiraCommunicator "github.com/desertjinn/jira-communicator/proto/jira-communicator"
+	datasourceCommunicator "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
+	"github.com/desertjinn/mavenlink-jira-sync/POGO"
+	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const OnboardSubcommand = "onboard"
+
+// Options of the onboard subcommand, the epic & board being asked for when they aren't given
+type OnboardingOptions struct {
+	Workspace   int
+	ProjectKey  string
+	Name        string
+	Epic        string
+	CreateEpic  bool
+	Board       string
+	CreateBoard bool
+	EpicMode    bool
+	Milestones  string
+}
+
+// Parse the arguments of the onboard subcommand
+func GetOnboardingOptions(args []string) (*OnboardingOptions, error) {
+	options := new(OnboardingOptions)
+	flags := flag.NewFlagSet(OnboardSubcommand, flag.ContinueOnError)
+	flags.IntVar(&options.Workspace, "workspace", 0, "ID of the Mavenlink workspace")
+	flags.StringVar(&options.ProjectKey, "project", "", "Key of the JIRA project")
+	flags.StringVar(&options.Name, "name", "", "Name of the sync configuration, the workspace's title by default")
+	flags.StringVar(&options.Epic, "epic", "", "Key or number of the existing JIRA epic to sync to")
+	flags.BoolVar(&options.CreateEpic, "create-epic", false, "Create the JIRA epic from the workspace's title")
+	flags.StringVar(&options.Board, "board", "", "ID or name of the existing JIRA board to sync sprints to")
+	flags.BoolVar(&options.CreateBoard, "create-board", false, "Create a JIRA board for the project")
+	flags.BoolVar(&options.EpicMode, "epic-mode", false, "Sync Mavenlink sub-tasks as JIRA epics, not sprints")
+	flags.StringVar(&options.Milestones, "milestones", utility.DefaultMilestone,
+		"Comma separated titles or IDs of the Mavenlink milestone tasks to sync")
+	if parseErr := flags.Parse(args); parseErr != nil {
+		return nil, parseErr
+	}
+	if options.Workspace <= 0 || len(strings.TrimSpace(options.ProjectKey)) == 0 {
+		return nil, errors.New("Both -workspace & -project are required")
+	}
+	if len(options.Epic) > 0 && options.CreateEpic {
+		return nil, errors.New("Use either -epic or -create-epic")
+	}
+	if len(options.Board) > 0 && options.CreateBoard {
+		return nil, errors.New("Use either -board or -create-board")
+	}
+	options.ProjectKey = strings.ToUpper(strings.TrimSpace(options.ProjectKey))
+	return options, nil
+}
+
+// Ask for one of the choices on the terminal, returning its index
+func promptForChoice(reader *bufio.Reader, question string, choices []string) (int, error) {
+	fmt.Println(question)
+	for choiceKey, choice := range choices {
+		fmt.Printf("%s%d) %s\n", utility.LevelOne, choiceKey+1, choice)
+	}
+	for {
+		fmt.Printf("Choose 1-%d: ", len(choices))
+		answer, answerErr := reader.ReadString('\n')
+		if answerErr != nil && (answerErr != io.EOF || len(answer) == 0) {
+			return 0, answerErr
+		}
+		choice, choiceErr := strconv.Atoi(strings.TrimSpace(answer))
+		if choiceErr == nil && choice >= 1 && choice <= len(choices) {
+			return choice - 1, nil
+		}
+	}
+}
+
+// Get the JIRA epic of the new configuration, picking an existing epic or creating one
+func (syncOps *SyncOperations) getOnboardingEpic(reader *bufio.Reader, options *OnboardingOptions,
+	syncConfiguration *datasourceCommunicator.ExternalProject) error {
+
+	if !options.CreateEpic && len(options.Epic) == 0 {
+		epics := make(chan []jiraCommunicator.Issue)
+		go syncOps.jira.RetrieveEpicsInProject(syncConfiguration.ProjectKey, epics)
+		existingEpics := <-epics
+		var choices []string
+		for _, epic := range existingEpics {
+			choices = append(choices, fmt.Sprintf("%s - %s", epic.Key, epic.Fields.Summary))
+		}
+		choices = append(choices, "Create a new epic from the Mavenlink workspace's title")
+		choice, choiceErr := promptForChoice(reader, fmt.Sprintf("JIRA epic of '%s'?",
+			syncConfiguration.ProjectName), choices)
+		if choiceErr != nil {
+			return choiceErr
+		}
+		if choice == len(existingEpics) {
+			options.CreateEpic = true
+		} else {
+			options.Epic = existingEpics[choice].Key
+		}
+	}
+	if options.CreateEpic {
+		_, createErr := syncOps.createProjectEpic(syncConfiguration)
+		return createErr
+	}
+	epicNumber := syncOps.common.GetIssueNumberFromKey(syncConfiguration.ProjectKey, options.Epic)
+	if epicNumber == 0 || syncOps.jira.GetEpicInJiraProject(
+		syncConfiguration.ProjectKey+"-"+fmt.Sprint(epicNumber)) == nil {
+		return errors.New(fmt.Sprintf("JIRA epic '%s' not found in project %s", options.Epic,
+			syncConfiguration.ProjectKey))
+	}
+	syncConfiguration.EpicId = epicNumber
+	return nil
+}
+
+// Get the JIRA board of the new configuration, picking an existing board or creating one
+func (syncOps *SyncOperations) getOnboardingBoard(reader *bufio.Reader, options *OnboardingOptions,
+	syncConfiguration *datasourceCommunicator.ExternalProject) error {
+
+	rapidViews := make(chan []jiraCommunicator.GreenhopperRapidView)
+	sprintsAndTasks := &POGO.SprintAndTask{}
+	go syncOps.jira.RetrieveRapidViewsInProject(syncConfiguration.ProjectKey, rapidViews)
+	sprintsAndTasks.SetRapidViews(<-rapidViews)
+	existingRapidViews := sprintsAndTasks.GetRapidViews()
+	if !options.CreateBoard && len(options.Board) == 0 && len(existingRapidViews) != 1 {
+		var choices []string
+		for _, rapidView := range existingRapidViews {
+			choices = append(choices, fmt.Sprintf("%d - %s", rapidView.Id, rapidView.Name))
+		}
+		choices = append(choices, "Create a new board for the project")
+		choice, choiceErr := promptForChoice(reader, fmt.Sprintf("JIRA board of '%s'?",
+			syncConfiguration.ProjectName), choices)
+		if choiceErr != nil {
+			return choiceErr
+		}
+		if choice == len(existingRapidViews) {
+			options.CreateBoard = true
+		} else {
+			options.Board = fmt.Sprint(existingRapidViews[choice].Id)
+		}
+	}
+	if options.CreateBoard {
+		justCreated := syncOps.jira.CreateRapidViewInJira(syncConfiguration.ProjectKey,
+			syncConfiguration.ProjectName)
+		if justCreated == nil {
+			return errors.New(fmt.Sprintf("Failed to create board '%s'", syncConfiguration.ProjectName))
+		}
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Check,
+			fmt.Sprintf("Created board %d '%s'", justCreated.Id, justCreated.Name))
+		syncConfiguration.Board = fmt.Sprint(justCreated.Id)
+		return nil
+	}
+	rapidView, rapidViewErr := syncOps.sprint.GetConfiguredRapidView(existingRapidViews, options.Board)
+	if rapidViewErr != nil {
+		return rapidViewErr
+	}
+	syncConfiguration.Board = fmt.Sprint(rapidView.Id)
+	return nil
+}
+
+// Create a sync configuration for a Mavenlink workspace & JIRA project, after checking both exist
+func (syncOps *SyncOperations) Onboard(options *OnboardingOptions,
+	input io.Reader) (*datasourceCommunicator.ExternalProject, error) {
+
+	reader := bufio.NewReader(input)
+	workspace := syncOps.mavenlink.GetWorkspaceInMavenlink(int32(options.Workspace))
+	jiraProject := syncOps.jira.GetJiraProjectFromKey(options.ProjectKey)
+	if workspace == nil {
+		return nil, errors.New(fmt.Sprintf("Mavenlink workspace %d not found", options.Workspace))
+	}
+	if jiraProject == nil {
+		return nil, errors.New(fmt.Sprintf("JIRA project %s not found", options.ProjectKey))
+	}
+	jiraProjectId64, jiraProjectId64Err := strconv.ParseInt(jiraProject.Id, 10, 32)
+	if jiraProjectId64Err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to read the ID(%s) of JIRA project %s", jiraProject.Id,
+			options.ProjectKey))
+	}
+
+	syncConfiguration := new(datasourceCommunicator.ExternalProject)
+	syncConfiguration.ProjectName = options.Name
+	if len(syncConfiguration.ProjectName) == 0 {
+		syncConfiguration.ProjectName = workspace.Title
+	}
+	syncConfiguration.Source1ProjectId = int32(jiraProjectId64)
+	syncConfiguration.Source2ProjectId = int32(options.Workspace)
+	syncConfiguration.ProjectKey = jiraProject.Key
+	syncConfiguration.Milestones = options.Milestones
+	syncConfiguration.EpicMode = options.EpicMode
+
+	validConfiguration := true
+	validity := make(chan bool)
+	go syncOps.mavenlink.DoesWorkspaceExistInMavenlink(syncConfiguration.Source2ProjectId, validity)
+	go syncOps.jira.DoesProjectExistInJira(syncConfiguration.Source1ProjectId, validity)
+	for i := 0; i < 2; i++ {
+		validConfiguration = syncOps.common.IsContinuouslyTrue(validConfiguration, <-validity)
+	}
+	if !validConfiguration {
+		return nil, errors.New(fmt.Sprintf("Mavenlink workspace %d or JIRA project %s can't be accessed",
+			options.Workspace, options.ProjectKey))
+	}
+	syncConfigurations, syncConfigurationsErr := syncOps.datasource.GetSyncConfiguration()
+	if syncConfigurationsErr != nil {
+		return nil, syncConfigurationsErr
+	}
+	for _, existing := range syncConfigurations {
+		if existing.DeleteFlag == 0 && existing.Source2ProjectId == syncConfiguration.Source2ProjectId &&
+			existing.Source1ProjectId == syncConfiguration.Source1ProjectId {
+			return nil, errors.New(fmt.Sprintf("Mavenlink workspace %d is already synced to %s as '%s'",
+				options.Workspace, options.ProjectKey, existing.ProjectName))
+		}
+	}
+
+	// Projects in epic mode have neither a project epic nor a board to sync sprints to
+	if !syncConfiguration.EpicMode {
+		if boardErr := syncOps.getOnboardingBoard(reader, options, syncConfiguration); boardErr != nil {
+			return nil, boardErr
+		}
+		if epicErr := syncOps.getOnboardingEpic(reader, options, syncConfiguration); epicErr != nil {
+			return nil, epicErr
+		}
+	}
+	return syncOps.datasource.SaveSyncConfiguration(syncConfiguration)
+}
+
+// Run the onboard subcommand
+func runOnboarding(syncOperations *SyncOperations, args []string) {
+	options, optionsErr := GetOnboardingOptions(args)
+	if optionsErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+			fmt.Sprintf("Invalid %s options: %v", OnboardSubcommand, optionsErr))
+		os.Exit(2)
+	}
+	syncConfiguration, onboardErr := syncOperations.Onboard(options, os.Stdin)
+	if onboardErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+			fmt.Sprintf("Failed to onboard Mavenlink workspace %d: %v", options.Workspace, onboardErr))
+		os.Exit(1)
+	}
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.ThumbsUp,
+		fmt.Sprintf("Onboarded '%s'(ID: %d), syncing Mavenlink workspace %d to JIRA project %s",
+			syncConfiguration.ProjectName, syncConfiguration.Id, syncConfiguration.Source2ProjectId,
+			syncConfiguration.ProjectKey))
+}
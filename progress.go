@@ -0,0 +1,60 @@
+package main
+
+import (
+	synchronizer "github.com/desertjinn/mavenlink-jira-sync/proto/mavenlink-jira-sync"
+)
+
+const (
+	RunEventPhase    = "phase"
+	RunEventCreated  = "created"
+	RunEventUpdated  = "updated"
+	RunEventFailed   = "failed"
+	RunEventFinished = "finished"
+)
+
+const (
+	RunPhaseBootstrapping = "bootstrapping"
+	RunPhaseSprints       = "sprints"
+	RunPhaseEpics         = "epics"
+	RunPhaseIssues        = "issues"
+	RunPhaseWorklogs      = "worklogs"
+)
+
+const (
+	RunItemSprint  = "sprint"
+	RunItemEpic    = "epic"
+	RunItemIssue   = "issue"
+	RunItemWorklog = "worklog"
+)
+
+// Receives the progress of the running syncs, a project never having two runs going at once
+type ProgressReporter interface {
+	ReportProgress(externalProjectId int32, event *synchronizer.RunEvent)
+}
+
+// Report the project's sync moving to a phase
+func (syncOps *SyncOperations) reportPhase(externalProjectId int32, phase string) {
+	if syncOps.progress == nil {
+		return
+	}
+	syncOps.progress.ReportProgress(externalProjectId, &synchronizer.RunEvent{
+		Type:  RunEventPhase,
+		Phase: phase,
+	})
+}
+
+// Report an item created, updated or failed during the project's sync
+func (syncOps *SyncOperations) reportItem(externalProjectId int32, eventType string, phase string, item string,
+	itemId string, message string) {
+
+	if syncOps.progress == nil {
+		return
+	}
+	syncOps.progress.ReportProgress(externalProjectId, &synchronizer.RunEvent{
+		Type:    eventType,
+		Phase:   phase,
+		Item:    item,
+		ItemId:  itemId,
+		Message: message,
+	})
+}
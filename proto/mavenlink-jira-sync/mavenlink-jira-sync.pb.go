@@ -7,6 +7,12 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 
+import (
+	client "github.com/micro/go-micro/client"
+	server "github.com/micro/go-micro/server"
+	context "golang.org/x/net/context"
+)
+
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
@@ -22,6 +28,8 @@ type EnvironmentConfiguration struct {
 	Debug                bool     `protobuf:"varint,1,opt,name=debug,proto3" json:"debug,omitempty"`
 	Master               string   `protobuf:"bytes,2,opt,name=master,proto3" json:"master,omitempty"`
 	Strict               bool     `protobuf:"varint,3,opt,name=strict,proto3" json:"strict,omitempty"`
+	FullSyncInterval     string   `protobuf:"bytes,4,opt,name=fullSyncInterval,proto3" json:"fullSyncInterval,omitempty"`
+	JiraSyncAccount      string   `protobuf:"bytes,5,opt,name=jiraSyncAccount,proto3" json:"jiraSyncAccount,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -31,7 +39,7 @@ func (m *EnvironmentConfiguration) Reset()         { *m = EnvironmentConfigurati
 func (m *EnvironmentConfiguration) String() string { return proto.CompactTextString(m) }
 func (*EnvironmentConfiguration) ProtoMessage()    {}
 func (*EnvironmentConfiguration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_jira_sync_7502bd6714d1c71f, []int{0}
+	return fileDescriptor_mavenlink_jira_sync_a4012a627bb278ae, []int{0}
 }
 func (m *EnvironmentConfiguration) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EnvironmentConfiguration.Unmarshal(m, b)
@@ -72,10 +80,25 @@ func (m *EnvironmentConfiguration) GetStrict() bool {
 	return false
 }
 
+func (m *EnvironmentConfiguration) GetFullSyncInterval() string {
+	if m != nil {
+		return m.FullSyncInterval
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetJiraSyncAccount() string {
+	if m != nil {
+		return m.JiraSyncAccount
+	}
+	return ""
+}
+
 type EquivalenceTypes struct {
 	IssueType            bool     `protobuf:"varint,1,opt,name=issueType,proto3" json:"issueType,omitempty"`
 	Status               bool     `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
 	Priority             bool     `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	ExternalProjectId    int32    `protobuf:"varint,4,opt,name=externalProjectId,proto3" json:"externalProjectId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -85,7 +108,7 @@ func (m *EquivalenceTypes) Reset()         { *m = EquivalenceTypes{} }
 func (m *EquivalenceTypes) String() string { return proto.CompactTextString(m) }
 func (*EquivalenceTypes) ProtoMessage()    {}
 func (*EquivalenceTypes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_jira_sync_7502bd6714d1c71f, []int{1}
+	return fileDescriptor_mavenlink_jira_sync_a4012a627bb278ae, []int{1}
 }
 func (m *EquivalenceTypes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EquivalenceTypes.Unmarshal(m, b)
@@ -126,28 +149,639 @@ func (m *EquivalenceTypes) GetPriority() bool {
 	return false
 }
 
+func (m *EquivalenceTypes) GetExternalProjectId() int32 {
+	if m != nil {
+		return m.ExternalProjectId
+	}
+	return 0
+}
+
+type SyncRequest struct {
+	ExternalProjectId    int32    `protobuf:"varint,1,opt,name=externalProjectId,proto3" json:"externalProjectId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncRequest) Reset()         { *m = SyncRequest{} }
+func (m *SyncRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncRequest) ProtoMessage()    {}
+func (*SyncRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_jira_sync_a4012a627bb278ae, []int{2}
+}
+func (m *SyncRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncRequest.Unmarshal(m, b)
+}
+func (m *SyncRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncRequest.Marshal(b, m, deterministic)
+}
+func (dst *SyncRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncRequest.Merge(dst, src)
+}
+func (m *SyncRequest) XXX_Size() int {
+	return xxx_messageInfo_SyncRequest.Size(m)
+}
+func (m *SyncRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncRequest proto.InternalMessageInfo
+
+func (m *SyncRequest) GetExternalProjectId() int32 {
+	if m != nil {
+		return m.ExternalProjectId
+	}
+	return 0
+}
+
+type RunRequest struct {
+	RunId                string   `protobuf:"bytes,1,opt,name=runId,proto3" json:"runId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunRequest) Reset()         { *m = RunRequest{} }
+func (m *RunRequest) String() string { return proto.CompactTextString(m) }
+func (*RunRequest) ProtoMessage()    {}
+func (*RunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_jira_sync_a4012a627bb278ae, []int{3}
+}
+func (m *RunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunRequest.Unmarshal(m, b)
+}
+func (m *RunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunRequest.Marshal(b, m, deterministic)
+}
+func (dst *RunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunRequest.Merge(dst, src)
+}
+func (m *RunRequest) XXX_Size() int {
+	return xxx_messageInfo_RunRequest.Size(m)
+}
+func (m *RunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunRequest proto.InternalMessageInfo
+
+func (m *RunRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+type RunsRequest struct {
+	ExternalProjectId    int32    `protobuf:"varint,1,opt,name=externalProjectId,proto3" json:"externalProjectId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunsRequest) Reset()         { *m = RunsRequest{} }
+func (m *RunsRequest) String() string { return proto.CompactTextString(m) }
+func (*RunsRequest) ProtoMessage()    {}
+func (*RunsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_jira_sync_a4012a627bb278ae, []int{4}
+}
+func (m *RunsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunsRequest.Unmarshal(m, b)
+}
+func (m *RunsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunsRequest.Marshal(b, m, deterministic)
+}
+func (dst *RunsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunsRequest.Merge(dst, src)
+}
+func (m *RunsRequest) XXX_Size() int {
+	return xxx_messageInfo_RunsRequest.Size(m)
+}
+func (m *RunsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunsRequest proto.InternalMessageInfo
+
+func (m *RunsRequest) GetExternalProjectId() int32 {
+	if m != nil {
+		return m.ExternalProjectId
+	}
+	return 0
+}
+
+type Run struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExternalProjectId    int32    `protobuf:"varint,2,opt,name=externalProjectId,proto3" json:"externalProjectId,omitempty"`
+	ProjectName          string   `protobuf:"bytes,3,opt,name=projectName,proto3" json:"projectName,omitempty"`
+	Trigger              string   `protobuf:"bytes,4,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	Status               string   `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	StartedAt            string   `protobuf:"bytes,6,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	FinishedAt           string   `protobuf:"bytes,7,opt,name=finishedAt,proto3" json:"finishedAt,omitempty"`
+	Target               string   `protobuf:"bytes,8,opt,name=target,proto3" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Run) Reset()         { *m = Run{} }
+func (m *Run) String() string { return proto.CompactTextString(m) }
+func (*Run) ProtoMessage()    {}
+func (*Run) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_jira_sync_a4012a627bb278ae, []int{5}
+}
+func (m *Run) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Run.Unmarshal(m, b)
+}
+func (m *Run) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Run.Marshal(b, m, deterministic)
+}
+func (dst *Run) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Run.Merge(dst, src)
+}
+func (m *Run) XXX_Size() int {
+	return xxx_messageInfo_Run.Size(m)
+}
+func (m *Run) XXX_DiscardUnknown() {
+	xxx_messageInfo_Run.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Run proto.InternalMessageInfo
+
+func (m *Run) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Run) GetExternalProjectId() int32 {
+	if m != nil {
+		return m.ExternalProjectId
+	}
+	return 0
+}
+
+func (m *Run) GetProjectName() string {
+	if m != nil {
+		return m.ProjectName
+	}
+	return ""
+}
+
+func (m *Run) GetTrigger() string {
+	if m != nil {
+		return m.Trigger
+	}
+	return ""
+}
+
+func (m *Run) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Run) GetStartedAt() string {
+	if m != nil {
+		return m.StartedAt
+	}
+	return ""
+}
+
+func (m *Run) GetFinishedAt() string {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return ""
+}
+
+func (m *Run) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+type RunsResponse struct {
+	Runs                 []*Run   `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunsResponse) Reset()         { *m = RunsResponse{} }
+func (m *RunsResponse) String() string { return proto.CompactTextString(m) }
+func (*RunsResponse) ProtoMessage()    {}
+func (*RunsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_jira_sync_a4012a627bb278ae, []int{6}
+}
+func (m *RunsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunsResponse.Unmarshal(m, b)
+}
+func (m *RunsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunsResponse.Marshal(b, m, deterministic)
+}
+func (dst *RunsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunsResponse.Merge(dst, src)
+}
+func (m *RunsResponse) XXX_Size() int {
+	return xxx_messageInfo_RunsResponse.Size(m)
+}
+func (m *RunsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunsResponse proto.InternalMessageInfo
+
+func (m *RunsResponse) GetRuns() []*Run {
+	if m != nil {
+		return m.Runs
+	}
+	return nil
+}
+
+type ValidationResponse struct {
+	ExternalProjectId    int32    `protobuf:"varint,1,opt,name=externalProjectId,proto3" json:"externalProjectId,omitempty"`
+	Valid                bool     `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidationResponse) Reset()         { *m = ValidationResponse{} }
+func (m *ValidationResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidationResponse) ProtoMessage()    {}
+func (*ValidationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_jira_sync_a4012a627bb278ae, []int{7}
+}
+func (m *ValidationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidationResponse.Unmarshal(m, b)
+}
+func (m *ValidationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidationResponse.Marshal(b, m, deterministic)
+}
+func (dst *ValidationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidationResponse.Merge(dst, src)
+}
+func (m *ValidationResponse) XXX_Size() int {
+	return xxx_messageInfo_ValidationResponse.Size(m)
+}
+func (m *ValidationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidationResponse proto.InternalMessageInfo
+
+func (m *ValidationResponse) GetExternalProjectId() int32 {
+	if m != nil {
+		return m.ExternalProjectId
+	}
+	return 0
+}
+
+func (m *ValidationResponse) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+type RunEvent struct {
+	RunId                string   `protobuf:"bytes,1,opt,name=runId,proto3" json:"runId,omitempty"`
+	ExternalProjectId    int32    `protobuf:"varint,2,opt,name=externalProjectId,proto3" json:"externalProjectId,omitempty"`
+	Type                 string   `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Phase                string   `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	Item                 string   `protobuf:"bytes,5,opt,name=item,proto3" json:"item,omitempty"`
+	ItemId               string   `protobuf:"bytes,6,opt,name=itemId,proto3" json:"itemId,omitempty"`
+	Message              string   `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	OccurredAt           string   `protobuf:"bytes,8,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunEvent) Reset()         { *m = RunEvent{} }
+func (m *RunEvent) String() string { return proto.CompactTextString(m) }
+func (*RunEvent) ProtoMessage()    {}
+func (*RunEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_jira_sync_a4012a627bb278ae, []int{8}
+}
+func (m *RunEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunEvent.Unmarshal(m, b)
+}
+func (m *RunEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunEvent.Marshal(b, m, deterministic)
+}
+func (dst *RunEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunEvent.Merge(dst, src)
+}
+func (m *RunEvent) XXX_Size() int {
+	return xxx_messageInfo_RunEvent.Size(m)
+}
+func (m *RunEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunEvent proto.InternalMessageInfo
+
+func (m *RunEvent) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *RunEvent) GetExternalProjectId() int32 {
+	if m != nil {
+		return m.ExternalProjectId
+	}
+	return 0
+}
+
+func (m *RunEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *RunEvent) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *RunEvent) GetItem() string {
+	if m != nil {
+		return m.Item
+	}
+	return ""
+}
+
+func (m *RunEvent) GetItemId() string {
+	if m != nil {
+		return m.ItemId
+	}
+	return ""
+}
+
+func (m *RunEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *RunEvent) GetOccurredAt() string {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*EnvironmentConfiguration)(nil), "EnvironmentConfiguration")
 	proto.RegisterType((*EquivalenceTypes)(nil), "EquivalenceTypes")
+	proto.RegisterType((*SyncRequest)(nil), "SyncRequest")
+	proto.RegisterType((*RunRequest)(nil), "RunRequest")
+	proto.RegisterType((*RunsRequest)(nil), "RunsRequest")
+	proto.RegisterType((*Run)(nil), "Run")
+	proto.RegisterType((*RunsResponse)(nil), "RunsResponse")
+	proto.RegisterType((*ValidationResponse)(nil), "ValidationResponse")
+	proto.RegisterType((*RunEvent)(nil), "RunEvent")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ client.Option
+var _ server.Option
+
+// Client API for MavenlinkJiraSync service
+
+type MavenlinkJiraSyncClient interface {
+	TriggerSync(ctx context.Context, in *SyncRequest, opts ...client.CallOption) (*Run, error)
+	GetRunStatus(ctx context.Context, in *RunRequest, opts ...client.CallOption) (*Run, error)
+	ListRuns(ctx context.Context, in *RunsRequest, opts ...client.CallOption) (*RunsResponse, error)
+	ValidateConfiguration(ctx context.Context, in *SyncRequest, opts ...client.CallOption) (*ValidationResponse, error)
+	WatchRun(ctx context.Context, in *RunRequest, opts ...client.CallOption) (MavenlinkJiraSync_WatchRunClient, error)
+}
+
+type mavenlinkJiraSyncClient struct {
+	c           client.Client
+	serviceName string
+}
+
+func NewMavenlinkJiraSyncClient(serviceName string, c client.Client) MavenlinkJiraSyncClient {
+	if c == nil {
+		c = client.NewClient()
+	}
+	if len(serviceName) == 0 {
+		serviceName = "mavenlinkjirasync"
+	}
+	return &mavenlinkJiraSyncClient{
+		c:           c,
+		serviceName: serviceName,
+	}
+}
+
+func (c *mavenlinkJiraSyncClient) TriggerSync(ctx context.Context, in *SyncRequest, opts ...client.CallOption) (*Run, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkJiraSync.TriggerSync", in)
+	out := new(Run)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mavenlinkJiraSyncClient) GetRunStatus(ctx context.Context, in *RunRequest, opts ...client.CallOption) (*Run, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkJiraSync.GetRunStatus", in)
+	out := new(Run)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mavenlinkJiraSyncClient) ListRuns(ctx context.Context, in *RunsRequest, opts ...client.CallOption) (*RunsResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkJiraSync.ListRuns", in)
+	out := new(RunsResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mavenlinkJiraSyncClient) ValidateConfiguration(ctx context.Context, in *SyncRequest, opts ...client.CallOption) (*ValidationResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkJiraSync.ValidateConfiguration", in)
+	out := new(ValidationResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mavenlinkJiraSyncClient) WatchRun(ctx context.Context, in *RunRequest, opts ...client.CallOption) (MavenlinkJiraSync_WatchRunClient, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkJiraSync.WatchRun", &RunRequest{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &mavenlinkJiraSyncWatchRunClient{stream}, nil
+}
+
+type MavenlinkJiraSync_WatchRunClient interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*RunEvent, error)
+}
+
+type mavenlinkJiraSyncWatchRunClient struct {
+	stream client.Streamer
+}
+
+func (x *mavenlinkJiraSyncWatchRunClient) Close() error {
+	return x.stream.Close()
+}
+
+func (x *mavenlinkJiraSyncWatchRunClient) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *mavenlinkJiraSyncWatchRunClient) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *mavenlinkJiraSyncWatchRunClient) Recv() (*RunEvent, error) {
+	m := new(RunEvent)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for MavenlinkJiraSync service
+
+type MavenlinkJiraSyncHandler interface {
+	TriggerSync(context.Context, *SyncRequest, *Run) error
+	GetRunStatus(context.Context, *RunRequest, *Run) error
+	ListRuns(context.Context, *RunsRequest, *RunsResponse) error
+	ValidateConfiguration(context.Context, *SyncRequest, *ValidationResponse) error
+	WatchRun(context.Context, *RunRequest, MavenlinkJiraSync_WatchRunStream) error
+}
+
+func RegisterMavenlinkJiraSyncHandler(s server.Server, hdlr MavenlinkJiraSyncHandler, opts ...server.HandlerOption) {
+	s.Handle(s.NewHandler(&MavenlinkJiraSync{hdlr}, opts...))
+}
+
+type MavenlinkJiraSync struct {
+	MavenlinkJiraSyncHandler
+}
+
+func (h *MavenlinkJiraSync) TriggerSync(ctx context.Context, in *SyncRequest, out *Run) error {
+	return h.MavenlinkJiraSyncHandler.TriggerSync(ctx, in, out)
+}
+
+func (h *MavenlinkJiraSync) GetRunStatus(ctx context.Context, in *RunRequest, out *Run) error {
+	return h.MavenlinkJiraSyncHandler.GetRunStatus(ctx, in, out)
+}
+
+func (h *MavenlinkJiraSync) ListRuns(ctx context.Context, in *RunsRequest, out *RunsResponse) error {
+	return h.MavenlinkJiraSyncHandler.ListRuns(ctx, in, out)
+}
+
+func (h *MavenlinkJiraSync) ValidateConfiguration(ctx context.Context, in *SyncRequest, out *ValidationResponse) error {
+	return h.MavenlinkJiraSyncHandler.ValidateConfiguration(ctx, in, out)
+}
+
+func (h *MavenlinkJiraSync) WatchRun(ctx context.Context, stream server.Streamer) error {
+	m := new(RunRequest)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.MavenlinkJiraSyncHandler.WatchRun(ctx, m, &mavenlinkJiraSyncWatchRunStream{stream})
+}
+
+type MavenlinkJiraSync_WatchRunStream interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*RunEvent) error
+}
+
+type mavenlinkJiraSyncWatchRunStream struct {
+	stream server.Streamer
+}
+
+func (x *mavenlinkJiraSyncWatchRunStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *mavenlinkJiraSyncWatchRunStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *mavenlinkJiraSyncWatchRunStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *mavenlinkJiraSyncWatchRunStream) Send(m *RunEvent) error {
+	return x.stream.Send(m)
 }
 
 func init() {
-	proto.RegisterFile("proto/mavenlink-jira-sync/mavenlink-jira-sync.proto", fileDescriptor_mavenlink_jira_sync_7502bd6714d1c71f)
-}
-
-var fileDescriptor_mavenlink_jira_sync_7502bd6714d1c71f = []byte{
-	// 196 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0xcf, 0x41, 0x6a, 0x85, 0x30,
-	0x10, 0x06, 0x60, 0x6c, 0xa9, 0x68, 0x56, 0x25, 0x94, 0x12, 0x4a, 0x17, 0xe2, 0xca, 0x8d, 0xed,
-	0xc2, 0x23, 0x14, 0x2f, 0x20, 0x3d, 0x40, 0xa3, 0xa6, 0x32, 0xad, 0x4e, 0xec, 0x64, 0x22, 0x78,
-	0xfb, 0x62, 0x0c, 0xef, 0x6d, 0xde, 0xf2, 0xfb, 0x19, 0xfe, 0x9f, 0x11, 0xcd, 0x4a, 0x96, 0xed,
-	0xfb, 0xa2, 0x37, 0x83, 0x33, 0xe0, 0x6f, 0xfd, 0x03, 0xa4, 0x6b, 0xb7, 0xe3, 0x70, 0x2b, 0x7b,
-	0x0b, 0xd7, 0xe5, 0x97, 0x50, 0x2d, 0x6e, 0x40, 0x16, 0x17, 0x83, 0xfc, 0x61, 0xf1, 0x1b, 0x26,
-	0x4f, 0x9a, 0xc1, 0xa2, 0x7c, 0x12, 0x0f, 0xa3, 0xe9, 0xfd, 0xa4, 0x92, 0x22, 0xa9, 0xb2, 0xee,
-	0x84, 0x7c, 0x16, 0xe9, 0xa2, 0x1d, 0x1b, 0x52, 0x77, 0x45, 0x52, 0xe5, 0x5d, 0xd4, 0x91, 0x3b,
-	0x26, 0x18, 0x58, 0xdd, 0x87, 0xf3, 0xa8, 0x72, 0x14, 0x8f, 0xed, 0x9f, 0x87, 0x4d, 0xcf, 0x06,
-	0x07, 0xf3, 0xb9, 0xaf, 0xc6, 0xc9, 0x57, 0x91, 0x83, 0x73, 0x3e, 0x28, 0xb6, 0x5f, 0x83, 0xb3,
-	0x49, 0xb3, 0x77, 0x61, 0x21, 0x34, 0x1d, 0x92, 0x2f, 0x22, 0x5b, 0x09, 0x2c, 0x01, 0xef, 0x71,
-	0xe3, 0xe2, 0x3e, 0x0d, 0xef, 0x34, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0x63, 0xf1, 0x13, 0x99,
-	0x05, 0x01, 0x00, 0x00,
+	proto.RegisterFile("proto/mavenlink-jira-sync/mavenlink-jira-sync.proto", fileDescriptor_mavenlink_jira_sync_a4012a627bb278ae)
+}
+
+var fileDescriptor_mavenlink_jira_sync_a4012a627bb278ae = []byte{
+	// 602 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9d, 0x54, 0xcb, 0x6e, 0xd3, 0x40,
+	0x14, 0x95, 0xf3, 0xaa, 0x73, 0x13, 0xa0, 0x1d, 0x1e, 0xb2, 0x22, 0x84, 0x8a, 0x85, 0x44, 0x84,
+	0xa8, 0x41, 0xed, 0x0e, 0x56, 0x15, 0x8a, 0x50, 0x11, 0x20, 0xe4, 0x56, 0xc0, 0x76, 0xea, 0x4c,
+	0x9c, 0x29, 0xf6, 0xd8, 0x9d, 0x19, 0x47, 0xe4, 0x37, 0xf8, 0x18, 0x3e, 0x87, 0x15, 0x5b, 0xfe,
+	0x81, 0x79, 0xb9, 0x71, 0x68, 0xb2, 0x28, 0xab, 0xcc, 0x39, 0x73, 0x5f, 0x39, 0xe7, 0x7a, 0xe0,
+	0xa8, 0xe4, 0x85, 0x2c, 0x5e, 0xe4, 0x78, 0x41, 0x58, 0x46, 0xd9, 0xb7, 0x83, 0x0b, 0xca, 0xf1,
+	0x81, 0x58, 0xb2, 0x64, 0x13, 0x17, 0x99, 0xe8, 0xf0, 0xa7, 0x07, 0xc1, 0x84, 0x2d, 0x28, 0x2f,
+	0x58, 0x4e, 0x98, 0x7c, 0x53, 0xb0, 0x19, 0x4d, 0x2b, 0x8e, 0x25, 0x2d, 0x18, 0xba, 0x07, 0xdd,
+	0x29, 0x39, 0xaf, 0xd2, 0xc0, 0xdb, 0xf7, 0xc6, 0x7e, 0x6c, 0x01, 0x7a, 0x00, 0xbd, 0x1c, 0x0b,
+	0x49, 0x78, 0xd0, 0x52, 0x74, 0x3f, 0x76, 0x48, 0xf3, 0x42, 0x72, 0x9a, 0xc8, 0xa0, 0x6d, 0xc2,
+	0x1d, 0x42, 0xcf, 0x60, 0x77, 0x56, 0x65, 0xd9, 0xa9, 0x6a, 0x7a, 0xc2, 0x54, 0xe0, 0x02, 0x67,
+	0x41, 0xc7, 0x64, 0x5e, 0xe3, 0xd1, 0x18, 0xee, 0xe8, 0x09, 0x35, 0x77, 0x9c, 0x24, 0x45, 0xc5,
+	0x64, 0xd0, 0x35, 0xa1, 0xff, 0xd2, 0xe1, 0x0f, 0x0f, 0x76, 0x27, 0x97, 0x15, 0x55, 0x59, 0x84,
+	0x25, 0xe4, 0x6c, 0x59, 0x12, 0x81, 0x1e, 0x42, 0x9f, 0x0a, 0x51, 0x19, 0xe4, 0x86, 0x5e, 0x11,
+	0x76, 0x40, 0x2c, 0x2b, 0x61, 0x06, 0x37, 0x03, 0x6a, 0x84, 0x46, 0xe0, 0x97, 0x9c, 0x16, 0x9c,
+	0xca, 0xa5, 0x1b, 0xfd, 0x0a, 0xa3, 0xe7, 0xb0, 0x47, 0xbe, 0xab, 0xe1, 0x18, 0xce, 0x3e, 0xf1,
+	0xe2, 0x82, 0x24, 0xf2, 0x64, 0x6a, 0xa6, 0xef, 0xc6, 0xd7, 0x2f, 0xc2, 0xd7, 0x30, 0xd0, 0x33,
+	0xc6, 0xe4, 0xb2, 0x22, 0x42, 0x6e, 0x4e, 0xf6, 0xb6, 0x25, 0x87, 0x00, 0x71, 0xc5, 0xea, 0x5c,
+	0xa5, 0x3d, 0xaf, 0x98, 0x8b, 0xef, 0xc7, 0x16, 0xe8, 0x06, 0x2a, 0x46, 0xfc, 0x5f, 0x83, 0x3f,
+	0x1e, 0xb4, 0x55, 0x36, 0xba, 0x0d, 0x2d, 0x5a, 0xd7, 0x55, 0xa7, 0xcd, 0x55, 0x5a, 0x5b, 0xaa,
+	0xa0, 0x7d, 0x18, 0x94, 0x16, 0x7c, 0xc4, 0x39, 0x31, 0x82, 0xf5, 0xe3, 0x26, 0x85, 0x02, 0xd8,
+	0x51, 0xce, 0xa7, 0xa9, 0xda, 0x10, 0xeb, 0x73, 0x0d, 0x1b, 0x0e, 0x58, 0x57, 0x6b, 0x07, 0x94,
+	0x6f, 0xea, 0xc4, 0x25, 0x99, 0x1e, 0xcb, 0xa0, 0x67, 0xae, 0x56, 0x04, 0x7a, 0x04, 0x30, 0xa3,
+	0x8c, 0x8a, 0xb9, 0xb9, 0xde, 0x31, 0xd7, 0x0d, 0x46, 0x57, 0x55, 0xb1, 0x29, 0x91, 0x81, 0x6f,
+	0xab, 0x5a, 0x14, 0x8e, 0x61, 0x68, 0xc5, 0x12, 0x65, 0xc1, 0x84, 0x9e, 0xab, 0xa3, 0x54, 0x14,
+	0xea, 0x9f, 0xb7, 0xc7, 0x83, 0xc3, 0x4e, 0xa4, 0xd5, 0x36, 0x4c, 0xf8, 0x15, 0xd0, 0x67, 0x9c,
+	0xd1, 0xa9, 0x59, 0xfb, 0xab, 0xf8, 0x1b, 0xa9, 0xab, 0x0d, 0x5b, 0xe8, 0x1a, 0x6e, 0xb9, 0x2c,
+	0x08, 0x7f, 0x79, 0xe0, 0xab, 0x3e, 0x13, 0xf5, 0xfd, 0x6d, 0xf1, 0xf4, 0x86, 0xf2, 0x23, 0xe8,
+	0x48, 0xbd, 0xdd, 0x56, 0x77, 0x73, 0xd6, 0x75, 0xcb, 0x39, 0x16, 0xc4, 0xc9, 0x6d, 0x81, 0x8e,
+	0xa4, 0x92, 0xe4, 0x4e, 0x6a, 0x73, 0xd6, 0x52, 0xe9, 0x5f, 0xd5, 0xc0, 0xaa, 0xec, 0x90, 0xb6,
+	0x2c, 0x27, 0x42, 0xe0, 0x94, 0x38, 0x7d, 0x6b, 0xa8, 0xc5, 0x2f, 0x92, 0xa4, 0xe2, 0xdc, 0x88,
+	0x6f, 0x05, 0x6e, 0x30, 0x87, 0xbf, 0x3d, 0xd8, 0xfb, 0x50, 0x3f, 0x2f, 0xef, 0xdc, 0x47, 0x8a,
+	0x1e, 0xc3, 0xe0, 0xcc, 0x7a, 0x6e, 0xe0, 0x30, 0x6a, 0x7c, 0x16, 0x23, 0xa3, 0xbc, 0x0a, 0x19,
+	0xbe, 0x25, 0x52, 0x9d, 0x4e, 0xed, 0x0e, 0x0c, 0xa2, 0xd5, 0xf6, 0xbb, 0x90, 0xa7, 0xe0, 0xbf,
+	0xa7, 0x42, 0xc7, 0x08, 0x55, 0xa2, 0xb1, 0xf8, 0xa3, 0x5b, 0xd1, 0x9a, 0xb3, 0xaf, 0xe0, 0xbe,
+	0xf3, 0x8f, 0xac, 0xbf, 0x60, 0xeb, 0x8d, 0xef, 0x46, 0x1b, 0x5c, 0x7e, 0x02, 0xfe, 0x17, 0x2c,
+	0x93, 0xb9, 0x6e, 0xb8, 0x36, 0x43, 0x3f, 0xaa, 0x8d, 0x7b, 0xe9, 0x9d, 0xf7, 0xcc, 0x73, 0x79,
+	0xf4, 0x17, 0xdb, 0xe1, 0x68, 0xbc, 0x65, 0x05, 0x00, 0x00,
 }
@@ -0,0 +1,508 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	datasourceCommunicator "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
+	synchronizer "github.com/desertjinn/mavenlink-jira-sync/proto/mavenlink-jira-sync"
+	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"github.com/micro/go-micro"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const ServeSubcommand = "serve"
+
+const (
+	RunTriggerSchedule = "schedule"
+	RunTriggerRequest  = "request"
+	RunTriggerWebhook  = "webhook"
+)
+
+const (
+	RunStatusRunning   = "running"
+	RunStatusSucceeded = "succeeded"
+	RunStatusFailed    = "failed"
+	RunStatusInvalid   = "invalid"
+)
+
+// Number of runs kept for inspection through the gRPC service
+const KeptRuns = 100
+
+// Time before retrying JIRA's metadata when it couldn't be retrieved on start
+const JiraMetadataRetryDelay = 30 * time.Second
+
+// Options of the serve subcommand
+type ServeOptions struct {
+	Interval time.Duration
+	Jitter   time.Duration
+	// Address the webhook receiver listens on, no receiver being started when it's empty
+	Webhooks      string
+	WebhookSecret string
+}
+
+// Parse the arguments of the serve subcommand
+func GetServeOptions(args []string) (*ServeOptions, error) {
+	options := new(ServeOptions)
+	flags := flag.NewFlagSet(ServeSubcommand, flag.ContinueOnError)
+	flags.DurationVar(&options.Interval, "interval", 10*time.Minute, "Time between the sync runs of a project")
+	flags.DurationVar(&options.Jitter, "jitter", time.Minute,
+		"Maximum random delay added to each run, spreading the projects' runs apart")
+	flags.StringVar(&options.Webhooks, "webhooks", "", "Address to receive Mavenlink & JIRA webhooks on, e.g. :8080")
+	flags.StringVar(&options.WebhookSecret, "webhook-secret", os.Getenv("WEBHOOK_SECRET"),
+		"Secret the webhooks must send as their 'secret' query parameter")
+	if parseErr := flags.Parse(args); parseErr != nil {
+		return nil, parseErr
+	}
+	if options.Interval <= 0 || options.Jitter < 0 {
+		return nil, errors.New("-interval must be positive & -jitter can't be negative")
+	}
+	// Anyone reaching the receiver could queue syncs without it
+	if len(options.Webhooks) > 0 && len(options.WebhookSecret) == 0 {
+		return nil, errors.New("-webhooks requires -webhook-secret or WEBHOOK_SECRET")
+	}
+	return options, nil
+}
+
+// Progress events of a run, kept for its watchers
+type runProgress struct {
+	events []*synchronizer.RunEvent
+	// Closed & replaced whenever the run progresses
+	progressed chan bool
+	finished   bool
+}
+
+// Schedules the sync runs of every configured project, never running a project twice at the same time
+type Scheduler struct {
+	syncOps   *SyncOperations
+	options   *ServeOptions
+	random    *rand.Rand
+	lock      sync.Mutex
+	scheduled map[int32]*time.Timer
+	running   map[int32]*synchronizer.Run
+	runs      []*synchronizer.Run
+	progress  map[string]*runProgress
+	stopped   bool
+}
+
+func NewScheduler(syncOps *SyncOperations, options *ServeOptions) *Scheduler {
+	return &Scheduler{
+		syncOps:   syncOps,
+		options:   options,
+		random:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		scheduled: map[int32]*time.Timer{},
+		running:   map[int32]*synchronizer.Run{},
+		progress:  map[string]*runProgress{},
+	}
+}
+
+// Get the delay before a project's next run, the interval plus a random jitter
+func (scheduler *Scheduler) getDelay(interval time.Duration) time.Duration {
+	if scheduler.options.Jitter <= 0 {
+		return interval
+	}
+	return interval + time.Duration(scheduler.random.Int63n(int64(scheduler.options.Jitter)))
+}
+
+// Record the start of a project's run, unless its previous run is still going
+func (scheduler *Scheduler) startRun(syncConfiguration *datasourceCommunicator.ExternalProject,
+	trigger string, target string) (*synchronizer.Run, error) {
+
+	scheduler.lock.Lock()
+	defer scheduler.lock.Unlock()
+	if scheduler.stopped {
+		return nil, errors.New("Syncs are being stopped")
+	}
+	if scheduler.running[syncConfiguration.Id] != nil {
+		return nil, errors.New(fmt.Sprintf("The previous run of '%s' is still going", syncConfiguration.ProjectName))
+	}
+	startedAt := time.Now()
+	run := &synchronizer.Run{
+		Id:                fmt.Sprintf("%d-%d", syncConfiguration.Id, startedAt.UnixNano()),
+		ExternalProjectId: syncConfiguration.Id,
+		ProjectName:       syncConfiguration.ProjectName,
+		Trigger:           trigger,
+		Status:            RunStatusRunning,
+		StartedAt:         startedAt.Format(time.RFC3339),
+		Target:            target,
+	}
+	scheduler.running[syncConfiguration.Id] = run
+	scheduler.runs = append(scheduler.runs, run)
+	scheduler.progress[run.Id] = &runProgress{progressed: make(chan bool)}
+	if len(scheduler.runs) > KeptRuns {
+		for _, dropped := range scheduler.runs[:len(scheduler.runs)-KeptRuns] {
+			delete(scheduler.progress, dropped.Id)
+		}
+		scheduler.runs = scheduler.runs[len(scheduler.runs)-KeptRuns:]
+	}
+	return run, nil
+}
+
+// Record an event of the run & wake its watchers, the lock being held
+func (scheduler *Scheduler) recordProgress(run *synchronizer.Run, event *synchronizer.RunEvent) {
+	progress := scheduler.progress[run.Id]
+	if progress == nil {
+		return
+	}
+	event.RunId = run.Id
+	event.ExternalProjectId = run.ExternalProjectId
+	event.OccurredAt = time.Now().Format(time.RFC3339)
+	progress.events = append(progress.events, event)
+	close(progress.progressed)
+	progress.progressed = make(chan bool)
+}
+
+// Record the progress of the project's running sync
+func (scheduler *Scheduler) ReportProgress(externalProjectId int32, event *synchronizer.RunEvent) {
+	scheduler.lock.Lock()
+	defer scheduler.lock.Unlock()
+	run := scheduler.running[externalProjectId]
+	if run == nil {
+		return
+	}
+	scheduler.recordProgress(run, event)
+}
+
+func (scheduler *Scheduler) finishRun(run *synchronizer.Run, status string) {
+	scheduler.lock.Lock()
+	defer scheduler.lock.Unlock()
+	delete(scheduler.running, run.ExternalProjectId)
+	run.Status = status
+	run.FinishedAt = time.Now().Format(time.RFC3339)
+	scheduler.recordProgress(run, &synchronizer.RunEvent{Type: RunEventFinished, Message: status})
+	if progress := scheduler.progress[run.Id]; progress != nil {
+		progress.finished = true
+	}
+}
+
+// Send the events of the run as it progresses, from its first event until it finishes or the watcher leaves
+func (scheduler *Scheduler) WatchRun(ctx context.Context, runId string,
+	send func(event *synchronizer.RunEvent) error) error {
+
+	var sent int
+	for {
+		scheduler.lock.Lock()
+		progress := scheduler.progress[runId]
+		if progress == nil {
+			scheduler.lock.Unlock()
+			return errors.New(fmt.Sprintf("Progress of run %s isn't kept", runId))
+		}
+		events := progress.events[sent:]
+		progressed := progress.progressed
+		finished := progress.finished
+		scheduler.lock.Unlock()
+
+		for _, event := range events {
+			if sendErr := send(event); sendErr != nil {
+				return sendErr
+			}
+		}
+		sent += len(events)
+		if finished {
+			return nil
+		}
+		select {
+		case <-progressed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Provision & validate the project of a started run unless a webhook triggered it, then sync it within the run's
+// deadline
+func (scheduler *Scheduler) executeRun(syncConfiguration *datasourceCommunicator.ExternalProject,
+	run *synchronizer.Run, sync func(syncOps *SyncOperations, success chan bool)) bool {
+
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.TriangularBulletPoint+utility.TriangularBulletPoint,
+		fmt.Sprintf("Processing configuration: %s(run %s)", syncConfiguration.ProjectName, run.Id))
+	runContext, cancelRun := context.WithTimeout(context.Background(), utility.RunTimeout)
+	defer cancelRun()
+	syncOps := scheduler.syncOps.WithContext(runContext)
+	// Provisioning & validating fetch the project's boards & epics, which a webhook's targeted sync leaves to the
+	// project's runs, the sync of a task whose epic or sub-task isn't synced yet waiting for them
+	if run.Trigger != RunTriggerWebhook {
+		if provisionErr := syncOps.ProvisionEpicIfRequired(syncConfiguration); provisionErr != nil {
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+				fmt.Sprintf("Failed to provision the JIRA epic for '%s': %v", syncConfiguration.ProjectName,
+					provisionErr))
+			scheduler.finishRun(run, RunStatusFailed)
+			return false
+		}
+		if !syncOps.IsAValidSyncConfiguration(syncConfiguration) {
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+				fmt.Sprintf("Configuration is invalid for '%s'", syncConfiguration.ProjectName))
+			scheduler.finishRun(run, RunStatusInvalid)
+			return false
+		}
+	}
+	// Buffered so a sync still going past the deadline doesn't block once it's done
+	success := make(chan bool, 1)
+	go sync(syncOps, success)
+	select {
+	case synced := <-success:
+		if synced {
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Check,
+				fmt.Sprintf("Successfully synced '%s'", syncConfiguration.ProjectName))
+			scheduler.finishRun(run, RunStatusSucceeded)
+			return true
+		}
+	case <-runContext.Done():
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+			fmt.Sprintf("Run of '%s' exceeded %v, its remaining calls fail", syncConfiguration.ProjectName,
+				utility.RunTimeout))
+	}
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+		fmt.Sprintf("Failed to successfully sync '%s'", syncConfiguration.ProjectName))
+	scheduler.finishRun(run, RunStatusFailed)
+	return false
+}
+
+// Sync the project now, reporting false without waiting when its previous run is still going
+func (scheduler *Scheduler) RunProject(syncConfiguration *datasourceCommunicator.ExternalProject) bool {
+	run, startErr := scheduler.startRun(syncConfiguration, RunTriggerSchedule, "")
+	if startErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+			fmt.Sprintf("Skipping '%s': %v", syncConfiguration.ProjectName, startErr))
+		return false
+	}
+	return scheduler.executeRun(syncConfiguration, run, func(syncOps *SyncOperations, success chan bool) {
+		syncOps.SyncMavenlinkToJira(syncConfiguration, success)
+	})
+}
+
+// Sync a single Mavenlink task of the project now, failing when a run of the project is still going
+func (scheduler *Scheduler) RunTask(syncConfiguration *datasourceCommunicator.ExternalProject,
+	taskId string) (bool, error) {
+
+	run, startErr := scheduler.startRun(syncConfiguration, RunTriggerWebhook, "Mavenlink task "+taskId)
+	if startErr != nil {
+		return false, startErr
+	}
+	return scheduler.executeRun(syncConfiguration, run, func(syncOps *SyncOperations, success chan bool) {
+		syncOps.SyncMavenlinkTaskToJira(syncConfiguration, taskId, success)
+	}), nil
+}
+
+// Start syncing the project in the background, failing when its previous run is still going
+func (scheduler *Scheduler) TriggerProject(
+	syncConfiguration *datasourceCommunicator.ExternalProject) (*synchronizer.Run, error) {
+
+	run, startErr := scheduler.startRun(syncConfiguration, RunTriggerRequest, "")
+	if startErr != nil {
+		return nil, startErr
+	}
+	started := *run
+	go scheduler.executeRun(syncConfiguration, run, func(syncOps *SyncOperations, success chan bool) {
+		syncOps.SyncMavenlinkToJira(syncConfiguration, success)
+	})
+	return &started, nil
+}
+
+// Get a copy of the run, nil when it isn't kept
+func (scheduler *Scheduler) GetRun(runId string) *synchronizer.Run {
+	scheduler.lock.Lock()
+	defer scheduler.lock.Unlock()
+	for _, run := range scheduler.runs {
+		if run.Id == runId {
+			kept := *run
+			return &kept
+		}
+	}
+	return nil
+}
+
+// Get copies of the kept runs of the project, or of every project when the ID is 0, latest first
+func (scheduler *Scheduler) GetRuns(externalProjectId int32) []*synchronizer.Run {
+	scheduler.lock.Lock()
+	defer scheduler.lock.Unlock()
+	var runs []*synchronizer.Run
+	for runKey := len(scheduler.runs) - 1; runKey >= 0; runKey-- {
+		if externalProjectId == 0 || scheduler.runs[runKey].ExternalProjectId == externalProjectId {
+			kept := *scheduler.runs[runKey]
+			runs = append(runs, &kept)
+		}
+	}
+	return runs
+}
+
+// Get the project's current sync configuration, nil when it's no longer configured
+func (scheduler *Scheduler) getSyncConfiguration(externalProjectId int32) *datasourceCommunicator.ExternalProject {
+	syncConfigurations, err := scheduler.syncOps.datasource.GetSyncConfiguration()
+	if err != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+			fmt.Sprintf("Failure processing sync configurations: %v", err))
+		return nil
+	}
+	for _, syncConfiguration := range syncConfigurations {
+		if syncConfiguration.Id == externalProjectId && syncConfiguration.DeleteFlag == 0 {
+			return syncConfiguration
+		}
+	}
+	return nil
+}
+
+// Run the project after the delay & schedule its next run once done
+func (scheduler *Scheduler) scheduleProject(externalProjectId int32, delay time.Duration) {
+	scheduler.lock.Lock()
+	defer scheduler.lock.Unlock()
+	if scheduler.stopped {
+		return
+	}
+	scheduler.scheduled[externalProjectId] = time.AfterFunc(delay, func() {
+		// Pick up changes to the configuration made since the last run
+		syncConfiguration := scheduler.getSyncConfiguration(externalProjectId)
+		if syncConfiguration == nil {
+			scheduler.lock.Lock()
+			delete(scheduler.scheduled, externalProjectId)
+			scheduler.lock.Unlock()
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+				fmt.Sprintf("Unscheduled external project(ID: %d), it's no longer configured", externalProjectId))
+			return
+		}
+		scheduler.RunProject(syncConfiguration)
+		scheduler.scheduleProject(externalProjectId, scheduler.getDelay(scheduler.options.Interval))
+	})
+}
+
+// Schedule the projects configured since the last check
+func (scheduler *Scheduler) scheduleNewProjects() {
+	syncConfigurations, err := scheduler.syncOps.datasource.GetSyncConfiguration()
+	if err != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+			fmt.Sprintf("Failure processing sync configurations: %v", err))
+		return
+	}
+	for _, syncConfiguration := range syncConfigurations {
+		if syncConfiguration.DeleteFlag != 0 {
+			continue
+		}
+		scheduler.lock.Lock()
+		_, isScheduled := scheduler.scheduled[syncConfiguration.Id]
+		scheduler.lock.Unlock()
+		if !isScheduled {
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.CircularBulletPoint,
+				fmt.Sprintf("Scheduled '%s' every %v", syncConfiguration.ProjectName, scheduler.options.Interval))
+			// Spread the first runs over the jitter instead of starting every project at once
+			scheduler.scheduleProject(syncConfiguration.Id, scheduler.getDelay(0))
+		}
+	}
+}
+
+// Stop scheduling runs & wait for the running ones to finish
+func (scheduler *Scheduler) Stop() {
+	scheduler.lock.Lock()
+	scheduler.stopped = true
+	for _, timer := range scheduler.scheduled {
+		timer.Stop()
+	}
+	scheduler.lock.Unlock()
+	for {
+		scheduler.lock.Lock()
+		running := len(scheduler.running)
+		scheduler.lock.Unlock()
+		if running == 0 {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// Pick up the issue types, statuses & priorities added to JIRA since the process started
+func (scheduler *Scheduler) refreshJiraMetadata() {
+	refreshContext, cancelRefresh := context.WithTimeout(context.Background(), utility.RunTimeout)
+	defer cancelRefresh()
+	if refreshErr := utility.GetUtilitiesSingleton().RefreshJiraMetadata(refreshContext); refreshErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+			fmt.Sprintf("Keeping JIRA's previous metadata: %v", refreshErr))
+	}
+}
+
+// Wait for JIRA's metadata, without which nothing can be synced, retrying until it's retrieved or the process is
+// asked to stop
+func waitForJiraMetadata(stop <-chan os.Signal) bool {
+	metadataErr := utility.GetUtilitiesSingleton().GetJiraMetadataError()
+	for metadataErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+			fmt.Sprintf("Retrying JIRA's metadata in %v: %v", JiraMetadataRetryDelay, metadataErr))
+		select {
+		case <-time.After(JiraMetadataRetryDelay):
+		case received := <-stop:
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+				fmt.Sprintf("Received %v while waiting for JIRA's metadata", received))
+			return false
+		}
+		refreshContext, cancelRefresh := context.WithTimeout(context.Background(), utility.RunTimeout)
+		metadataErr = utility.GetUtilitiesSingleton().RefreshJiraMetadata(refreshContext)
+		cancelRefresh()
+	}
+	return true
+}
+
+// Keep scheduling the configured projects until the process is asked to stop
+func (scheduler *Scheduler) Serve(stop <-chan os.Signal) {
+	scheduler.scheduleNewProjects()
+	refresh := time.NewTicker(scheduler.options.Interval)
+	defer refresh.Stop()
+	for {
+		select {
+		case <-refresh.C:
+			scheduler.refreshJiraMetadata()
+			scheduler.scheduleNewProjects()
+		case received := <-stop:
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+				fmt.Sprintf("Received %v, waiting for running syncs to finish", received))
+			scheduler.Stop()
+			return
+		}
+	}
+}
+
+// Run the serve subcommand, scheduling syncs & serving the synchronizer's gRPC service
+func runServer(syncOperations *SyncOperations, args []string) {
+	options, optionsErr := GetServeOptions(args)
+	if optionsErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+			fmt.Sprintf("Invalid %s options: %v", ServeSubcommand, optionsErr))
+		os.Exit(2)
+	}
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	if !waitForJiraMetadata(stop) {
+		return
+	}
+	// Each run gets its own deadline, the calls made outside runs being bound by go-micro's request timeout alone
+	syncOperations = syncOperations.WithContext(context.Background())
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.TriangularBulletPoint,
+		fmt.Sprintf("Serving Mavenlink →→ JIRA syncs every %v(+ up to %v)", options.Interval, options.Jitter))
+
+	scheduler := NewScheduler(syncOperations, options)
+	syncOperations.progress = scheduler
+	service := micro.NewService(micro.Name(utility.SyncService))
+	synchronizer.RegisterMavenlinkJiraSyncHandler(service.Server(),
+		&SyncHandler{syncOps: syncOperations, scheduler: scheduler})
+	if len(options.Webhooks) > 0 {
+		receiver := NewWebhookReceiver(syncOperations, scheduler, options.WebhookSecret)
+		go receiver.Listen(options.Webhooks)
+	}
+
+	served := make(chan bool)
+	go func() {
+		scheduler.Serve(stop)
+		served <- true
+	}()
+	// The service runs until the process is asked to stop
+	if runErr := service.Run(); runErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+			fmt.Sprintf("Failed to run the %s service: %v", utility.SyncService, runErr))
+		stop <- syscall.SIGTERM
+	}
+	<-served
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.ThumbsUp, "Stopped serving")
+}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	jiraCommunicator "github.com/desertjinn/jira-communicator/proto/jira-communicator"
 	datasource "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
+	"github.com/desertjinn/mavenlink-jira-sync/POGO"
 	"github.com/desertjinn/mavenlink-jira-sync/functions"
 	"github.com/desertjinn/mavenlink-jira-sync/utility"
 	"github.com/pkg/errors"
+	"golang.org/x/net/context"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,31 +20,66 @@ const (
 
 type DataSourceServiceInterface interface {
 	GetSyncConfiguration() ([]*datasource.ExternalProject, error)
+	SaveSyncConfiguration(syncConfiguration *datasource.ExternalProject) (*datasource.ExternalProject, error)
+	SaveEpicOfSyncConfiguration(syncConfiguration *datasource.ExternalProject) error
+	GetEquivalenceConfiguration(externalProjectId int32) (map[string]map[string][]string, error)
+	GetIdentityMapping(externalProjectId int32) (*POGO.IdentityMapping, error)
+	GetSyncCursors(externalProjectId int32) (map[string]*datasource.ExternalSyncCursors, error)
+	SaveSyncCursor(externalProjectId int32, entityType string, updatedSince string) bool
 	SaveSprintAndTaskSyncHistory(projectId int32, sprint *jiraCommunicator.SprintWithMeta) bool
-	SaveIssueAndTaskSyncHistory(projectId int32, sprintId string, parentTaskId int32, taskId int32,
+	SaveEpicAndTaskSyncHistory(projectId int32, epic *jiraCommunicator.IssueWithMeta, epicId string) bool
+	SaveIssueAndTaskSyncHistory(projectId int32, sprintId string, epicId string, parentTaskId int32, taskId int32,
 		issue *jiraCommunicator.Issue) bool
 	UpdateIssueAndTaskSyncHistory(externalProjectId int32, project *jiraCommunicator.Project,
-		issue *jiraCommunicator.IssueWithMeta, sprintId string) error
+		issue *jiraCommunicator.IssueWithMeta, sprintId string, epicId string) error
+	GetIssueAndTaskSyncHistory(externalProjectId int32) []*datasource.ExternalTasks
+	GetSyncedTaskFromMavenlinkTaskId(taskId int32) *datasource.ExternalTasks
+	GetSyncedTaskFromJiraIssueId(issueId int32) *datasource.ExternalTasks
+	GetSyncedTimeEntryFromMavenlinkTimeEntryId(timeEntryId int32) *datasource.ExternalTimeEntries
+	DeleteIssueAndTaskSyncHistory(syncedTask *datasource.ExternalTasks) error
 	GetJiraSprintIdFromMavenlinkTaskId(parentId int32) string
+	GetJiraEpicIdFromMavenlinkTaskId(parentId int32) string
 	GetMavenlinkParentTaskIdFromMavenlinkTaskId(taskId int32) int32
 	GetJiraEpicKeyFromMavenlinkTaskId(taskId int32) string
 	GetTaskIdsFromSprintId(sprintId string) (string, string)
 	GetJiraIssueFromTaskInSubTask(projectKey string, taskInSubTask string) <-chan jiraCommunicator.Issue
 	SaveWorklogAndTimeEntrySyncHistory(issueId string, worklogId string, timeentryId string, jiraUserId string,
-		mavenlinkUserId string, timeLogged int64) bool
-	UpdateWorklogAndTimeEntrySyncHistory(issueId string, worklogId string,
-		timeEntryId string, jiraUserId string, mavenlinkUserId string, timeLogged int64) bool
+		mavenlinkUserId string, mavenlinkUserEmail string, fallbackAuthor bool, timeLogged int64) bool
+	UpdateWorklogAndTimeEntrySyncHistory(issueId string, worklogId string, timeEntryId string, jiraUserId string,
+		mavenlinkUserId string, mavenlinkUserEmail string, fallbackAuthor bool, timeLogged int64) bool
+	GetWorklogAndTimeEntrySyncHistory(issueId string) []*datasource.ExternalTimeEntries
+	DeleteWorklogAndTimeEntrySyncHistory(syncedWorklog *datasource.ExternalTimeEntries) bool
+	SaveCommentAndPostSyncHistory(issueId string, commentId string, taskId string, postId string,
+		postUpdated string) bool
+	UpdateCommentAndPostSyncHistory(issueId string, commentId string, postId string, postUpdated string) bool
+	GetAttachmentFromContentHash(issueId string, contentHash string) *datasource.ExternalAttachments
+	SaveAttachmentSyncHistory(issueId string, jiraAttachmentId string, taskId string,
+		mavenlinkAttachmentId string, contentHash string) bool
 }
 type DataSourceService struct {
 	cf functions.CommonFunctions
-	jiraService JiraService
+	// Context of the run the service is bound to
+	Context context.Context
+}
+
+// Get the context of the run the service is bound to, the process' one when it isn't bound to a run
+func (dataSourceService *DataSourceService) getContext() context.Context {
+	if dataSourceService.Context != nil {
+		return dataSourceService.Context
+	}
+	return utility.GetUtilitiesSingleton().CommsContext
+}
+
+// Get a JIRA service bound to the same run
+func (dataSourceService *DataSourceService) getJiraService() *JiraService {
+	return &JiraService{Context: dataSourceService.Context}
 }
 
 func (dataSourceService *DataSourceService) GetSyncConfiguration() ([]*datasource.ExternalProject, error) {
 	var projectsResponse *datasource.Response
 	var projects []*datasource.ExternalProject
 	projectsResponse, projectsResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.GetAll(
-		utility.GetUtilitiesSingleton().CommsContext, &datasource.Request{})
+		dataSourceService.getContext(), &datasource.Request{})
 	if projectsResponseErr != nil {
 		return projects, projectsResponseErr
 	}
@@ -49,6 +87,160 @@ func (dataSourceService *DataSourceService) GetSyncConfiguration() ([]*datasourc
 	return projects, nil
 }
 
+// Save a new sync configuration
+func (dataSourceService *DataSourceService) SaveSyncConfiguration(
+	syncConfiguration *datasource.ExternalProject) (*datasource.ExternalProject, error) {
+
+	syncedProject := *syncConfiguration
+	syncedProject.DeleteFlag = 0
+	syncedProject.CreatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+	syncedProject.UpdatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+	projectResponse, projectResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.Create(
+		dataSourceService.getContext(), &syncedProject)
+	if projectResponseErr != nil {
+		return nil, projectResponseErr
+	}
+	if projectResponse.Error != nil || projectResponse.Project == nil {
+		return nil, errors.New(fmt.Sprintf("Failed to save sync configuration '%s'", syncConfiguration.ProjectName))
+	}
+	return projectResponse.Project, nil
+}
+
+// Save the JIRA epic of an existing sync configuration
+func (dataSourceService *DataSourceService) SaveEpicOfSyncConfiguration(
+	syncConfiguration *datasource.ExternalProject) error {
+
+	syncedProject := *syncConfiguration
+	syncedProject.CreatedDtTm = dataSourceService.cf.ParseDateForInsertingInDb(syncConfiguration.CreatedDtTm)
+	syncedProject.UpdatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+	projectResponse, projectResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.Update(
+		dataSourceService.getContext(), &syncedProject)
+	if projectResponseErr != nil {
+		return projectResponseErr
+	}
+	if projectResponse.Error != nil {
+		return errors.New(fmt.Sprintf("Failed to save epic %d of external project(ID: %d)",
+			syncConfiguration.EpicId, syncConfiguration.Id))
+	}
+	return nil
+}
+
+// Retrieve the Mavenlink → JIRA equivalence relations configured for an external project(0 for the global default)
+func (dataSourceService *DataSourceService) GetEquivalenceConfiguration(externalProjectId int32) (
+	map[string]map[string][]string, error) {
+
+	relations := make(map[string]map[string][]string)
+	existingEquivalence := datasource.ExternalEquivalences{}
+	existingEquivalence.ExternalProjectId = externalProjectId
+	equivalencesResponse, equivalencesResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
+		GetEquivalences(dataSourceService.getContext(), &existingEquivalence)
+	if equivalencesResponseErr != nil {
+		return relations, equivalencesResponseErr
+	}
+	if equivalencesResponse.Error != nil {
+		return relations, errors.New(fmt.Sprintf("Failed to retrieve equivalences of external project(ID: %d)",
+			externalProjectId))
+	}
+	for _, equivalence := range equivalencesResponse.Equivalences {
+		if equivalence.DeleteFlag != 0 || equivalence.ExternalProjectId != externalProjectId {
+			continue
+		}
+		if _, ok := relations[equivalence.EquivalenceType]; !ok {
+			relations[equivalence.EquivalenceType] = make(map[string][]string)
+		}
+		mavenlink := strings.ToLower(equivalence.Source2Value)
+		relations[equivalence.EquivalenceType][mavenlink] = append(
+			relations[equivalence.EquivalenceType][mavenlink], strings.ToLower(equivalence.Source1Value))
+	}
+	return relations, nil
+}
+
+// Retrieve the Mavenlink → JIRA user links & domain aliases, the project's own overriding the global ones(ID 0),
+// nil when any of them fails to be retrieved
+func (dataSourceService *DataSourceService) GetIdentityMapping(externalProjectId int32) (*POGO.IdentityMapping, error) {
+	identities := new(POGO.IdentityMapping)
+	for _, projectId := range []int32{0, externalProjectId} {
+		existingUser := datasource.ExternalUsers{}
+		existingUser.ExternalProjectId = projectId
+		usersResponse, usersResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.GetUsers(
+			dataSourceService.getContext(), &existingUser)
+		if usersResponseErr != nil {
+			return nil, usersResponseErr
+		}
+		if usersResponse.Error != nil {
+			return nil, errors.New(fmt.Sprintf("Failed to retrieve users of external project(ID: %d)",
+				projectId))
+		}
+		for _, user := range usersResponse.Users {
+			if user.DeleteFlag != 0 || user.ExternalProjectId != projectId {
+				continue
+			}
+			identities.AddUser(fmt.Sprint(user.Source2UserId),
+				jiraCommunicator.Author{Name: user.Source1UserName, AccountId: user.Source1AccountId})
+		}
+
+		existingAlias := datasource.ExternalDomainAliases{}
+		existingAlias.ExternalProjectId = projectId
+		aliasesResponse, aliasesResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
+			GetDomainAliases(dataSourceService.getContext(), &existingAlias)
+		if aliasesResponseErr != nil {
+			return nil, aliasesResponseErr
+		}
+		if aliasesResponse.Error != nil {
+			return nil, errors.New(fmt.Sprintf(
+				"Failed to retrieve domain aliases of external project(ID: %d)", projectId))
+		}
+		for _, alias := range aliasesResponse.DomainAliases {
+			if alias.DeleteFlag != 0 || alias.ExternalProjectId != projectId {
+				continue
+			}
+			identities.AddDomainAlias(alias.Source2Domain, alias.Source1Domain)
+		}
+	}
+	return identities, nil
+}
+
+// Retrieve the high-water marks of an external project, keyed by their entity type
+func (dataSourceService *DataSourceService) GetSyncCursors(externalProjectId int32) (
+	map[string]*datasource.ExternalSyncCursors, error) {
+
+	cursors := map[string]*datasource.ExternalSyncCursors{}
+	existingCursor := datasource.ExternalSyncCursors{}
+	existingCursor.ExternalProjectId = externalProjectId
+	cursorsResponse, cursorsResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.GetSyncCursors(
+		dataSourceService.getContext(), &existingCursor)
+	if cursorsResponseErr != nil {
+		return cursors, cursorsResponseErr
+	}
+	if cursorsResponse.Error != nil {
+		return cursors, errors.New(fmt.Sprintf("Failed to retrieve sync cursors of external project(ID: %d)",
+			externalProjectId))
+	}
+	for _, cursor := range cursorsResponse.SyncCursors {
+		if cursor.DeleteFlag != 0 || cursor.ExternalProjectId != externalProjectId {
+			continue
+		}
+		cursors[cursor.EntityType] = cursor
+	}
+	return cursors, nil
+}
+
+// Save the high-water mark of an entity type of the external project, replacing its previous mark
+func (dataSourceService *DataSourceService) SaveSyncCursor(externalProjectId int32, entityType string,
+	updatedSince string) bool {
+
+	syncedCursor := datasource.ExternalSyncCursors{}
+	syncedCursor.ExternalProjectId = externalProjectId
+	syncedCursor.EntityType = entityType
+	syncedCursor.UpdatedSince = updatedSince
+	syncedCursor.DeleteFlag = 0
+	syncedCursor.CreatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+	syncedCursor.UpdatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+	cursorResponse, cursorResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.SaveSyncCursor(
+		dataSourceService.getContext(), &syncedCursor)
+	return cursorResponseErr == nil && cursorResponse.Error == nil && cursorResponse.SyncCursor != nil
+}
+
 func (dataSourceService *DataSourceService) SaveSprintAndTaskSyncHistory(projectId int32,
 	sprint *jiraCommunicator.SprintWithMeta) bool {
 
@@ -66,27 +258,74 @@ func (dataSourceService *DataSourceService) SaveSprintAndTaskSyncHistory(project
 		syncedTask.Source2ParentTaskId = sprint.MavenlinkParentTaskId
 	}
 	tasksResponse, tasksResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.CreateTaskAndSprint(
-		utility.GetUtilitiesSingleton().CommsContext, &syncedTask)
+		dataSourceService.getContext(), &syncedTask)
 	if tasksResponseErr == nil && tasksResponse.Error == nil && tasksResponse.Task != nil {
 		saved = true
 	}
 	return saved
 }
 
-func (dataSourceService *DataSourceService) SaveIssueAndTaskSyncHistory(projectId int32, sprintId string,
-	parentTaskId int32, taskId int32, issue *jiraCommunicator.Issue) bool {
+func (dataSourceService *DataSourceService) SaveEpicAndTaskSyncHistory(projectId int32,
+	epic *jiraCommunicator.IssueWithMeta, epicId string) bool {
 
 	var saved bool
 	var tasksResponse *datasource.Response
 	syncedTask := datasource.ExternalTasks{}
-	sprintId64, sprintId64Err := strconv.ParseInt(sprintId, 10, 32)
-	if nil != sprintId64Err {
+	epicId64, epicId64Err := strconv.ParseInt(epicId, 10, 32)
+	if nil != epicId64Err {
 		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
-			fmt.Sprintf("Error converting sprint ID: %s to int32", sprintId))
+			fmt.Sprintf("Error converting epic ID: %s to int32", epicId))
+		return false
+	}
+	syncedTask.Source1EpicId = int32(epicId64)
+	syncedTask.Type = 0
+	syncedTask.DeleteFlag = 0
+	syncedTask.CreatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+	syncedTask.UpdatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+	syncedTask.ExternalProjectId = projectId
+	if epic.MavenlinkTaskId != 0 && epic.MavenlinkParentTaskId != 0 {
+		syncedTask.Source2TaskId = epic.MavenlinkTaskId
+		syncedTask.Source2ParentTaskId = epic.MavenlinkParentTaskId
+	}
+	tasksResponse, tasksResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.CreateTaskAndEpic(
+		dataSourceService.getContext(), &syncedTask)
+	if tasksResponseErr == nil && tasksResponse.Error == nil && tasksResponse.Task != nil {
+		saved = true
+	}
+	return saved
+}
+
+// Set the JIRA sprint or epic an issue is part of, the sprint taking precedence when both are given
+func setJiraParentOfSyncedTask(syncedTask *datasource.ExternalTasks, sprintId string, epicId string) error {
+	if len(sprintId) > 0 {
+		sprintId64, sprintId64Err := strconv.ParseInt(sprintId, 10, 32)
+		if nil != sprintId64Err {
+			return errors.New(fmt.Sprintf("Failed to convert sprint ID: %s to 32-bit integer", sprintId))
+		}
+		syncedTask.Source1SprintId = int32(sprintId64)
+		syncedTask.Source1ParentTaskId = int32(sprintId64)
+		return nil
+	}
+	epicId64, epicId64Err := strconv.ParseInt(epicId, 10, 32)
+	if nil != epicId64Err {
+		return errors.New(fmt.Sprintf("Failed to convert epic ID: %s to 32-bit integer", epicId))
+	}
+	syncedTask.Source1EpicId = int32(epicId64)
+	syncedTask.Source1ParentTaskId = int32(epicId64)
+	return nil
+}
+
+func (dataSourceService *DataSourceService) SaveIssueAndTaskSyncHistory(projectId int32, sprintId string,
+	epicId string, parentTaskId int32, taskId int32, issue *jiraCommunicator.Issue) bool {
+
+	var saved bool
+	var tasksResponse *datasource.Response
+	syncedTask := datasource.ExternalTasks{}
+	parentErr := setJiraParentOfSyncedTask(&syncedTask, sprintId, epicId)
+	if nil != parentErr {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross, parentErr.Error())
 		return false
 	}
-	syncedTask.Source1SprintId = int32(sprintId64)
-	syncedTask.Source1ParentTaskId = int32(sprintId64)
 	issueId64, issueId64Err := strconv.ParseInt(issue.Id, 10, 32)
 	if nil != issueId64Err {
 		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
@@ -102,7 +341,7 @@ func (dataSourceService *DataSourceService) SaveIssueAndTaskSyncHistory(projectI
 	syncedTask.Source2ParentTaskId = parentTaskId
 	syncedTask.Source2TaskId = taskId
 	tasksResponse, tasksResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.CreateTaskAndIssue(
-		utility.GetUtilitiesSingleton().CommsContext, &syncedTask)
+		dataSourceService.getContext(), &syncedTask)
 	if tasksResponseErr == nil && tasksResponse.Error == nil && tasksResponse.Task != nil {
 		saved = true
 	}
@@ -110,18 +349,14 @@ func (dataSourceService *DataSourceService) SaveIssueAndTaskSyncHistory(projectI
 }
 
 func (dataSourceService *DataSourceService) UpdateIssueAndTaskSyncHistory(externalProjectId int32,
-	project *jiraCommunicator.Project, issue *jiraCommunicator.IssueWithMeta, sprintId string) error {
+	project *jiraCommunicator.Project, issue *jiraCommunicator.IssueWithMeta, sprintId string, epicId string) error {
 
 	var tasksResponse *datasource.Response
 	existingTask := datasource.ExternalTasks{}
 	existingTask.ExternalProjectId = externalProjectId
-	sprintId64, sprintId64Err := strconv.ParseInt(sprintId, 10, 32)
-	if nil != sprintId64Err {
-		return errors.New("Failed to convert sprint ID to 32-bit integer")
-	}
 	existingTask.Source2TaskId = issue.MavenlinkTaskId
 	existingTaskResponse, existingTaskResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
-		GetTaskInSubTaskFromId(utility.GetUtilitiesSingleton().CommsContext, &existingTask)
+		GetTaskInSubTaskFromId(dataSourceService.getContext(), &existingTask)
 	if existingTaskResponseErr != nil || existingTaskResponse.Error != nil || existingTaskResponse.Task == nil {
 		return errors.New(
 			"Failed to retrieve external task information from Mavenlink task and JIRA sprint information")
@@ -132,15 +367,18 @@ func (dataSourceService *DataSourceService) UpdateIssueAndTaskSyncHistory(extern
 			"Failed to parse created(%s) date to desired layout(2006-01-02 03:04:05) for update",
 			existingTaskResponse.Task.CreatedDtTm))
 	}
-	issueId := dataSourceService.jiraService.GetJiraIssueIdFromProjectKeyAndIssueKey(project.Key, issue.ExistingIssueKey)
+	issueId := dataSourceService.getJiraService().GetJiraIssueIdFromProjectKeyAndIssueKey(project.Key,
+		issue.ExistingIssueKey)
 	if issueId == 0 {
 		return errors.New("Failed to retrieve JIRA issue ID for update")
 	}
 
 	syncedTask := datasource.ExternalTasks{}
 	syncedTask.Id = existingTaskResponse.Task.Id
-	syncedTask.Source1SprintId = int32(sprintId64)
-	syncedTask.Source1ParentTaskId = int32(sprintId64)
+	parentErr := setJiraParentOfSyncedTask(&syncedTask, sprintId, epicId)
+	if nil != parentErr {
+		return parentErr
+	}
 	syncedTask.Source1TaskId = issueId
 	syncedTask.Type = 0
 	syncedTask.DeleteFlag = 0
@@ -150,31 +388,119 @@ func (dataSourceService *DataSourceService) UpdateIssueAndTaskSyncHistory(extern
 	syncedTask.Source2ParentTaskId = issue.MavenlinkParentTaskId
 	syncedTask.Source2TaskId = issue.MavenlinkTaskId
 	tasksResponse, tasksResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.UpdateTaskAndIssue(
-		utility.GetUtilitiesSingleton().CommsContext, &syncedTask)
+		dataSourceService.getContext(), &syncedTask)
 	if tasksResponseErr != nil && tasksResponse.Error != nil {
 		return errors.New(fmt.Sprintf("Failed to update external task(ID: %d)", issueId))
 	}
 	return nil
 }
 
+func (dataSourceService *DataSourceService) GetIssueAndTaskSyncHistory(
+	externalProjectId int32) []*datasource.ExternalTasks {
+
+	var syncedTasks []*datasource.ExternalTasks
+	existingTask := datasource.ExternalTasks{}
+	existingTask.ExternalProjectId = externalProjectId
+	tasksResponse, tasksResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
+		GetTasksAndIssues(dataSourceService.getContext(), &existingTask)
+	if nil == tasksResponseErr && nil == tasksResponse.Error && nil != tasksResponse.Tasks {
+		for _, syncedTask := range tasksResponse.Tasks {
+			if syncedTask.DeleteFlag == 0 && syncedTask.Source1TaskId != 0 {
+				syncedTasks = append(syncedTasks, syncedTask)
+			}
+		}
+	}
+	return syncedTasks
+}
+
+// Get the sync history of a Mavenlink task in a sub-task, nil when it isn't synced
+func (dataSourceService *DataSourceService) GetSyncedTaskFromMavenlinkTaskId(taskId int32) *datasource.ExternalTasks {
+	syncedTask := datasource.ExternalTasks{}
+	syncedTask.Source2TaskId = taskId
+	taskResponse, taskResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
+		GetTaskInSubTaskFromId(dataSourceService.getContext(), &syncedTask)
+	if nil == taskResponseErr && nil == taskResponse.Error && nil != taskResponse.Task &&
+		0 != taskResponse.Task.Id && 0 == taskResponse.Task.DeleteFlag {
+
+		return taskResponse.Task
+	}
+	return nil
+}
+
+// Get the sync history of the Mavenlink task a JIRA issue was synced from, nil when it isn't synced
+func (dataSourceService *DataSourceService) GetSyncedTaskFromJiraIssueId(issueId int32) *datasource.ExternalTasks {
+	syncedTask := datasource.ExternalTasks{}
+	syncedTask.Source1TaskId = issueId
+	taskResponse, taskResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
+		GetTaskInSubTaskFromIssueId(dataSourceService.getContext(), &syncedTask)
+	if nil == taskResponseErr && nil == taskResponse.Error && nil != taskResponse.Task &&
+		0 != taskResponse.Task.Id && 0 == taskResponse.Task.DeleteFlag {
+
+		return taskResponse.Task
+	}
+	return nil
+}
+
+// Get the sync history of a Mavenlink time entry, nil when it isn't synced
+func (dataSourceService *DataSourceService) GetSyncedTimeEntryFromMavenlinkTimeEntryId(
+	timeEntryId int32) *datasource.ExternalTimeEntries {
+
+	syncedTimeEntry := datasource.ExternalTimeEntries{}
+	syncedTimeEntry.Source2LogId = timeEntryId
+	timeEntryResponse, timeEntryResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
+		GetTimeentry(dataSourceService.getContext(), &syncedTimeEntry)
+	if nil == timeEntryResponseErr && nil == timeEntryResponse.Error && nil != timeEntryResponse.Timeentry &&
+		0 != timeEntryResponse.Timeentry.Id && 0 == timeEntryResponse.Timeentry.DeleteFlag {
+
+		return timeEntryResponse.Timeentry
+	}
+	return nil
+}
+
+func (dataSourceService *DataSourceService) DeleteIssueAndTaskSyncHistory(syncedTask *datasource.ExternalTasks) error {
+	deletedTask := *syncedTask
+	deletedTask.DeleteFlag = 1
+	deletedTask.CreatedDtTm = dataSourceService.cf.ParseDateForInsertingInDb(syncedTask.CreatedDtTm)
+	deletedTask.UpdatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+	tasksResponse, tasksResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.UpdateTaskAndIssue(
+		dataSourceService.getContext(), &deletedTask)
+	if tasksResponseErr != nil || tasksResponse.Error != nil {
+		return errors.New(fmt.Sprintf("Failed to flag external task(ID: %d) as deleted", syncedTask.Id))
+	}
+	return nil
+}
+
 func (dataSourceService *DataSourceService) GetJiraSprintIdFromMavenlinkTaskId(parentId int32) string {
 	var sprintId string
 	syncedTask := datasource.ExternalTasks{}
 	syncedTask.Source2TaskId = parentId
 	parentTasksResponse, parentTasksResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
-		GetTaskIfExists(utility.GetUtilitiesSingleton().CommsContext, &syncedTask)
+		GetTaskIfExists(dataSourceService.getContext(), &syncedTask)
 	if nil == parentTasksResponseErr && nil == parentTasksResponse.Error && nil != parentTasksResponse.Task {
 		sprintId = fmt.Sprint(parentTasksResponse.Task.Source1SprintId)
 	}
 	return sprintId
 }
 
+func (dataSourceService *DataSourceService) GetJiraEpicIdFromMavenlinkTaskId(parentId int32) string {
+	var epicId string
+	syncedTask := datasource.ExternalTasks{}
+	syncedTask.Source2TaskId = parentId
+	parentTasksResponse, parentTasksResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
+		GetTaskIfExists(dataSourceService.getContext(), &syncedTask)
+	if nil == parentTasksResponseErr && nil == parentTasksResponse.Error && nil != parentTasksResponse.Task &&
+		0 != parentTasksResponse.Task.Source1EpicId {
+		epicId = fmt.Sprint(parentTasksResponse.Task.Source1EpicId)
+	}
+	return epicId
+}
+
 func (dataSourceService *DataSourceService) GetMavenlinkParentTaskIdFromMavenlinkTaskId(taskId int32) int32 {
 	var parentTaskId int32
 	syncedTask := datasource.ExternalTasks{}
 	syncedTask.Source2TaskId = taskId
 	parentTasksResponse, parentTasksResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
-		GetTaskInSubTaskFromId(utility.GetUtilitiesSingleton().CommsContext, &syncedTask)
+		GetTaskInSubTaskFromId(dataSourceService.getContext(), &syncedTask)
 	if nil == parentTasksResponseErr && nil == parentTasksResponse.Error && nil != parentTasksResponse.Task {
 		parentTaskId = parentTasksResponse.Task.Source2ParentTaskId
 	}
@@ -187,11 +513,11 @@ func (dataSourceService *DataSourceService) GetJiraEpicKeyFromMavenlinkTaskId(ta
 	syncedProject := datasource.ExternalProject{}
 	syncedTask.Source2TaskId = taskId
 	syncedTaskResponse, syncedTaskResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
-		GetTaskInSubTaskFromId(utility.GetUtilitiesSingleton().CommsContext, &syncedTask)
+		GetTaskInSubTaskFromId(dataSourceService.getContext(), &syncedTask)
 	if nil == syncedTaskResponseErr && nil == syncedTaskResponse.Error && nil != syncedTaskResponse.Task {
 		syncedProject.Id = syncedTaskResponse.Task.ExternalProjectId
 		syncedProjectResponse, syncedProjectResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
-			Get(utility.GetUtilitiesSingleton().CommsContext, &syncedProject)
+			Get(dataSourceService.getContext(), &syncedProject)
 		if nil == syncedProjectResponseErr && nil == syncedProjectResponse.Error &&
 			nil != syncedProjectResponse.Project {
 
@@ -209,7 +535,7 @@ func (dataSourceService *DataSourceService) GetTaskIdsFromSprintId(sprintId stri
 	if nil == sprintId64Err {
 		syncedTask.Source1SprintId = int32(sprintId64)
 		parentTasksResponse, parentTasksResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
-			GetSprintIfExists(utility.GetUtilitiesSingleton().CommsContext, &syncedTask)
+			GetSprintIfExists(dataSourceService.getContext(), &syncedTask)
 		if nil == parentTasksResponseErr && nil == parentTasksResponse.Error && nil != parentTasksResponse.Task {
 			taskId = fmt.Sprint(parentTasksResponse.Task.Source2TaskId)
 			parentTaskId = fmt.Sprint(parentTasksResponse.Task.Source2ParentTaskId)
@@ -227,16 +553,18 @@ func (dataSourceService *DataSourceService) GetJiraIssueFromTaskInSubTask(projec
 		taskInSubTaskId64, taskInSubTaskId64Err := strconv.ParseInt(taskInSubTask, 10, 32)
 		if nil != taskInSubTaskId64Err {
 			issueChannel <- jiraCommunicator.Issue{}
+			return
 		}
 		syncedTask.Source2TaskId = int32(taskInSubTaskId64)
 		taskInSubTaskResponse, taskInSubTaskResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
-			GetTaskInSubTaskFromId(utility.GetUtilitiesSingleton().CommsContext, &syncedTask)
+			GetTaskInSubTaskFromId(dataSourceService.getContext(), &syncedTask)
 		if nil == taskInSubTaskResponseErr && nil == taskInSubTaskResponse.Error &&
 			nil != taskInSubTaskResponse && nil != taskInSubTaskResponse.Task {
-			issue := dataSourceService.jiraService.RetrieveIssueInProject(projectKey,
+			issue := dataSourceService.getJiraService().RetrieveIssueInProject(projectKey,
 				fmt.Sprint(taskInSubTaskResponse.Task.Source1TaskId))
 			if issue != nil {
 				issueChannel <- *issue
+				return
 			}
 		}
 		issueChannel <- jiraCommunicator.Issue{}
@@ -245,7 +573,8 @@ func (dataSourceService *DataSourceService) GetJiraIssueFromTaskInSubTask(projec
 }
 
 func (dataSourceService *DataSourceService) SaveWorklogAndTimeEntrySyncHistory(issueId string, worklogId string,
-	timeEntryId string, jiraUserId string, mavenlinkUserId string, timeLogged int64) bool {
+	timeEntryId string, jiraUserId string, mavenlinkUserId string, mavenlinkUserEmail string, fallbackAuthor bool,
+	timeLogged int64) bool {
 
 	var saved bool
 	var worklogsResponse *datasource.Response
@@ -275,13 +604,17 @@ func (dataSourceService *DataSourceService) SaveWorklogAndTimeEntrySyncHistory(i
 	syncedWorklog.Source2UserId = int32(mavenlinkUserId64)
 
 	syncedWorklog.Source1UserId = jiraUserId
+	syncedWorklog.Source2UserEmail = mavenlinkUserEmail
+	if fallbackAuthor {
+		syncedWorklog.FallbackFlag = 1
+	}
 	syncedWorklog.LoggedTime = timeLogged
 	syncedWorklog.DeleteFlag = 0
 	syncedWorklog.CreatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
 	syncedWorklog.UpdatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
 
 	worklogsResponse, tasksResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.CreateTimeentryAndWorklog(
-		utility.GetUtilitiesSingleton().CommsContext, &syncedWorklog)
+		dataSourceService.getContext(), &syncedWorklog)
 	if tasksResponseErr == nil && worklogsResponse.Error == nil && worklogsResponse.Timeentry != nil {
 		saved = true
 	}
@@ -289,7 +622,8 @@ func (dataSourceService *DataSourceService) SaveWorklogAndTimeEntrySyncHistory(i
 }
 
 func (dataSourceService *DataSourceService) UpdateWorklogAndTimeEntrySyncHistory(issueId string, worklogId string,
-	timeEntryId string, jiraUserId string, mavenlinkUserId string, timeLogged int64) bool {
+	timeEntryId string, jiraUserId string, mavenlinkUserId string, mavenlinkUserEmail string, fallbackAuthor bool,
+	timeLogged int64) bool {
 
 	var saved bool
 	var worklogsResponse *datasource.Response
@@ -317,7 +651,7 @@ func (dataSourceService *DataSourceService) UpdateWorklogAndTimeEntrySyncHistory
 	existingWorklog.Source2LogId = int32(timeentryId64)
 	existingWorklogsResponse, existingWorklogsResponseErr :=
 		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetTimeentry(
-			utility.GetUtilitiesSingleton().CommsContext, &existingWorklog)
+			dataSourceService.getContext(), &existingWorklog)
 	if existingWorklogsResponseErr != nil || existingWorklogsResponse.Error != nil ||
 		existingWorklogsResponse.Timeentry == nil || existingWorklogsResponse.Timeentry.Id == 0 {
 		return false
@@ -331,6 +665,10 @@ func (dataSourceService *DataSourceService) UpdateWorklogAndTimeEntrySyncHistory
 
 	syncedWorklog.Id = existingWorklogsResponse.Timeentry.Id
 	syncedWorklog.Source1UserId = jiraUserId
+	syncedWorklog.Source2UserEmail = mavenlinkUserEmail
+	if fallbackAuthor {
+		syncedWorklog.FallbackFlag = 1
+	}
 	syncedWorklog.LoggedTime = timeLogged
 	syncedWorklog.DeleteFlag = 0
 	syncedWorklog.CreatedDtTm = dataSourceService.cf.ParseDateForInsertingInDb(
@@ -339,9 +677,200 @@ func (dataSourceService *DataSourceService) UpdateWorklogAndTimeEntrySyncHistory
 
 	worklogsResponse, tasksResponseErr :=
 		utility.GetUtilitiesSingleton().ConfigurationDatasource.UpdateTimeentryAndWorklog(
-			utility.GetUtilitiesSingleton().CommsContext, &syncedWorklog)
+			dataSourceService.getContext(), &syncedWorklog)
 	if tasksResponseErr == nil && worklogsResponse.Error == nil && worklogsResponse.Timeentry != nil {
 		saved = true
 	}
 	return saved
 }
+
+func (dataSourceService *DataSourceService) GetWorklogAndTimeEntrySyncHistory(
+	issueId string) []*datasource.ExternalTimeEntries {
+
+	var syncedWorklogs []*datasource.ExternalTimeEntries
+	existingWorklog := datasource.ExternalTimeEntries{}
+	issueId64, issueId64Err := strconv.ParseInt(issueId, 10, 32)
+	if nil != issueId64Err {
+		return syncedWorklogs
+	}
+	existingWorklog.Source1TaskId = int32(issueId64)
+	worklogsResponse, worklogsResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
+		GetTimeentriesForIssue(dataSourceService.getContext(), &existingWorklog)
+	if nil == worklogsResponseErr && nil == worklogsResponse.Error && nil != worklogsResponse.Timeentries {
+		for _, syncedWorklog := range worklogsResponse.Timeentries {
+			if syncedWorklog.DeleteFlag == 0 {
+				syncedWorklogs = append(syncedWorklogs, syncedWorklog)
+			}
+		}
+	}
+	return syncedWorklogs
+}
+
+func (dataSourceService *DataSourceService) DeleteWorklogAndTimeEntrySyncHistory(
+	syncedWorklog *datasource.ExternalTimeEntries) bool {
+
+	var saved bool
+	deletedWorklog := *syncedWorklog
+	deletedWorklog.DeleteFlag = 1
+	deletedWorklog.CreatedDtTm = dataSourceService.cf.ParseDateForInsertingInDb(syncedWorklog.CreatedDtTm)
+	deletedWorklog.UpdatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+	worklogsResponse, worklogsResponseErr :=
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.UpdateTimeentryAndWorklog(
+			dataSourceService.getContext(), &deletedWorklog)
+	if worklogsResponseErr == nil && worklogsResponse.Error == nil && worklogsResponse.Timeentry != nil {
+		saved = true
+	}
+	return saved
+}
+
+func (dataSourceService *DataSourceService) SaveCommentAndPostSyncHistory(issueId string, commentId string,
+	taskId string, postId string, postUpdated string) bool {
+
+	var saved bool
+	var commentsResponse *datasource.Response
+	syncedComment := datasource.ExternalComments{}
+	issueId64, issueId64Err := strconv.ParseInt(issueId, 10, 32)
+	if nil != issueId64Err {
+		return false
+	}
+	syncedComment.Source1TaskId = int32(issueId64)
+
+	commentId64, commentId64Err := strconv.ParseInt(commentId, 10, 32)
+	if nil != commentId64Err {
+		return false
+	}
+	syncedComment.Source1CommentId = int32(commentId64)
+
+	taskId64, taskId64Err := strconv.ParseInt(taskId, 10, 32)
+	if nil != taskId64Err {
+		return false
+	}
+	syncedComment.Source2TaskId = int32(taskId64)
+
+	postId64, postId64Err := strconv.ParseInt(postId, 10, 32)
+	if nil != postId64Err {
+		return false
+	}
+	syncedComment.Source2PostId = int32(postId64)
+
+	syncedComment.Source2UpdatedDtTm = dataSourceService.cf.ParsePostUpdatedForInsertingInDb(postUpdated)
+	syncedComment.DeleteFlag = 0
+	syncedComment.CreatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+	syncedComment.UpdatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+
+	commentsResponse, commentsResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.CreateCommentAndPost(
+		dataSourceService.getContext(), &syncedComment)
+	if commentsResponseErr == nil && commentsResponse.Error == nil && commentsResponse.Comment != nil {
+		saved = true
+	}
+	return saved
+}
+
+func (dataSourceService *DataSourceService) UpdateCommentAndPostSyncHistory(issueId string, commentId string,
+	postId string, postUpdated string) bool {
+
+	var saved bool
+	var commentsResponse *datasource.Response
+
+	postId64, postId64Err := strconv.ParseInt(postId, 10, 32)
+	if nil != postId64Err {
+		return false
+	}
+	existingComment := datasource.ExternalComments{}
+	existingComment.Source2PostId = int32(postId64)
+	existingCommentsResponse, existingCommentsResponseErr :=
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.GetComment(
+			dataSourceService.getContext(), &existingComment)
+	if existingCommentsResponseErr != nil || existingCommentsResponse.Error != nil ||
+		existingCommentsResponse.Comment == nil || existingCommentsResponse.Comment.Id == 0 {
+		return false
+	}
+
+	syncedComment := *existingCommentsResponse.Comment
+	issueId64, issueId64Err := strconv.ParseInt(issueId, 10, 32)
+	if nil != issueId64Err {
+		return false
+	}
+	syncedComment.Source1TaskId = int32(issueId64)
+
+	commentId64, commentId64Err := strconv.ParseInt(commentId, 10, 32)
+	if nil != commentId64Err {
+		return false
+	}
+	syncedComment.Source1CommentId = int32(commentId64)
+
+	syncedComment.Source2UpdatedDtTm = dataSourceService.cf.ParsePostUpdatedForInsertingInDb(postUpdated)
+	syncedComment.CreatedDtTm = dataSourceService.cf.ParseDateForInsertingInDb(
+		existingCommentsResponse.Comment.CreatedDtTm)
+	syncedComment.UpdatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+
+	commentsResponse, commentsResponseErr :=
+		utility.GetUtilitiesSingleton().ConfigurationDatasource.UpdateCommentAndPost(
+			dataSourceService.getContext(), &syncedComment)
+	if commentsResponseErr == nil && commentsResponse.Error == nil && commentsResponse.Comment != nil {
+		saved = true
+	}
+	return saved
+}
+
+func (dataSourceService *DataSourceService) GetAttachmentFromContentHash(issueId string,
+	contentHash string) *datasource.ExternalAttachments {
+
+	syncedAttachment := datasource.ExternalAttachments{}
+	issueId64, issueId64Err := strconv.ParseInt(issueId, 10, 32)
+	if nil != issueId64Err {
+		return nil
+	}
+	syncedAttachment.Source1TaskId = int32(issueId64)
+	syncedAttachment.ContentHash = contentHash
+	attachmentResponse, attachmentResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
+		GetAttachmentFromHash(dataSourceService.getContext(), &syncedAttachment)
+	if nil == attachmentResponseErr && nil == attachmentResponse.Error && nil != attachmentResponse.Attachment &&
+		attachmentResponse.Attachment.Id != 0 {
+		return attachmentResponse.Attachment
+	}
+	return nil
+}
+
+func (dataSourceService *DataSourceService) SaveAttachmentSyncHistory(issueId string, jiraAttachmentId string,
+	taskId string, mavenlinkAttachmentId string, contentHash string) bool {
+
+	var saved bool
+	var attachmentsResponse *datasource.Response
+	syncedAttachment := datasource.ExternalAttachments{}
+	issueId64, issueId64Err := strconv.ParseInt(issueId, 10, 32)
+	if nil != issueId64Err {
+		return false
+	}
+	syncedAttachment.Source1TaskId = int32(issueId64)
+
+	jiraAttachmentId64, jiraAttachmentId64Err := strconv.ParseInt(jiraAttachmentId, 10, 32)
+	if nil != jiraAttachmentId64Err {
+		return false
+	}
+	syncedAttachment.Source1AttachmentId = int32(jiraAttachmentId64)
+
+	taskId64, taskId64Err := strconv.ParseInt(taskId, 10, 32)
+	if nil != taskId64Err {
+		return false
+	}
+	syncedAttachment.Source2TaskId = int32(taskId64)
+
+	mavenlinkAttachmentId64, mavenlinkAttachmentId64Err := strconv.ParseInt(mavenlinkAttachmentId, 10, 32)
+	if nil != mavenlinkAttachmentId64Err {
+		return false
+	}
+	syncedAttachment.Source2AttachmentId = int32(mavenlinkAttachmentId64)
+
+	syncedAttachment.ContentHash = contentHash
+	syncedAttachment.DeleteFlag = 0
+	syncedAttachment.CreatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+	syncedAttachment.UpdatedDtTm = time.Now().Format(DATE_TIME_FORMAT)
+
+	attachmentsResponse, attachmentsResponseErr := utility.GetUtilitiesSingleton().ConfigurationDatasource.
+		CreateAttachmentAndFile(dataSourceService.getContext(), &syncedAttachment)
+	if attachmentsResponseErr == nil && attachmentsResponse.Error == nil && attachmentsResponse.Attachment != nil {
+		saved = true
+	}
+	return saved
+}
@@ -5,33 +5,66 @@ import (
 	communicator "github.com/desertjinn/jira-communicator/proto/jira-communicator"
 	"github.com/desertjinn/mavenlink-jira-sync/utility"
 	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"io"
+	"net/http"
 	"strconv"
+	"time"
 )
 
 type JiraServiceInterface interface {
 	GetJiraProject(projectId int32) *communicator.Project
+	GetJiraProjectFromKey(projectKey string) *communicator.Project
+	CreateRapidViewInJira(projectKey string, name string) *communicator.GreenhopperRapidView
 	CreateSprintInJira(rapidViewId string) *communicator.Sprint
 	CreateIssueInJira(issue *communicator.IssueCreate) *communicator.Issue
 	CreateWorklogInJira(issueKey string, worklog *communicator.WorklogWithMeta) *communicator.Worklog
 	UpdateWorklogInJira(issueKey string, worklog *communicator.WorklogWithMeta) *communicator.Worklog
+	DeleteWorklogInJira(issueKey string, worklogId string) bool
+	CreateCommentInJira(issueKey string, body string) *communicator.Comment
+	UpdateCommentInJira(issueKey string, commentId string, body string) *communicator.Comment
+	UploadAttachmentToJira(issueKey string, fileName string, content io.Reader) *communicator.Attachment
 	UpdateIssueInJira(issue *communicator.IssueCreate) bool
+	GetTransitionsForJiraIssue(issueKey string) []*communicator.Transition
+	TransitionJiraIssue(issueKey string, transitionId string) bool
+	GetAllowedIssueTypesForJiraIssue(issueKey string) []*communicator.IssueType
+	ChangeIssueTypeInJira(issueKey string, issueTypeId string) bool
 	UpdateSprintInJira(sprint *communicator.SprintWithMeta) error
 	DoesProjectExistInJira(projectId int32, exists chan bool)
 	DoesEpicExistInJiraProject(epicKey string, exists chan bool)
 	GetEpicInJiraProject(epicKey string) *communicator.Issue
+	FindEpicInJiraProject(epicKey string) (*communicator.Issue, error)
 	RetrieveRapidViewsInProject(projectKey string, views chan []communicator.GreenhopperRapidView)
 	RetrieveSprintsInProject(projectKey string, sprints chan []communicator.Sprint)
 	RetrieveIssuesFromSprintInProject(projectKey string, sprintName string, issues chan []communicator.Issue)
+	RetrieveEpicsInProject(projectKey string, epics chan []communicator.Issue)
+	RetrieveIssuesFromEpicInProject(projectKey string, epicKey string, issues chan []communicator.Issue)
 	RetrieveIssueInProject(projectKey string, issueId string) *communicator.Issue
+	RetrieveIssuesUpdatedSinceInProject(projectKey string, updatedSince time.Time) ([]communicator.Issue, error)
+	RetrieveIssuesWithLabelInProject(projectKey string, label string) ([]communicator.Issue, error)
 	UpdateSprintInfoForJiraIssue(sprintId string, issueKey string) bool
 	UpdateEpicInfoForJiraIssue(epicKey string, issueKey string) bool
+	CloseIssueInJira(issueKey string) bool
+	DeleteIssueInJira(issueKey string) bool
+	AddLabelToJiraIssue(issueKey string, label string) bool
 	GetJiraIssueIdFromProjectKeyAndIssueKey(projectKey string, issueKey string) int32
 	GetWorklogsFromIssue(issueKey string, worklogs chan []communicator.Worklog)
 	GetUsersInProject(projectName string, users chan []communicator.Author)
 	GetJiraStatusMetadata(projectId string, statuses chan []communicator.Status)
 	GetJiraPriorityMetadata(projectId string, priorities chan []communicator.Priority)
 }
-type JiraService struct {}
+type JiraService struct {
+	// Context of the run the service is bound to
+	Context context.Context
+}
+
+// Get the context of the run the service is bound to, the process' one when it isn't bound to a run
+func (jiraService *JiraService) getContext() context.Context {
+	if jiraService.Context != nil {
+		return jiraService.Context
+	}
+	return utility.GetUtilitiesSingleton().CommsContext
+}
 
 func (jiraService *JiraService) GetJiraProject(projectId int32) *communicator.Project {
 	var project *communicator.Project
@@ -39,19 +72,45 @@ func (jiraService *JiraService) GetJiraProject(projectId int32) *communicator.Pr
 	var projectRequest communicator.Request
 	projectRequest.Project = fmt.Sprint(projectId)
 	jiraProjectResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetProject(
-		utility.GetUtilitiesSingleton().CommsContext, &projectRequest)
+		jiraService.getContext(), &projectRequest)
 	if err == nil && jiraProjectResponse.Error == nil && jiraProjectResponse.Project != nil {
 		project = jiraProjectResponse.Project
 	}
 	return project
 }
 
+func (jiraService *JiraService) GetJiraProjectFromKey(projectKey string) *communicator.Project {
+	var projectRequest communicator.Request
+	projectRequest.Project = projectKey
+	jiraProjectResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetProject(
+		jiraService.getContext(), &projectRequest)
+	if err == nil && jiraProjectResponse.Error == nil && jiraProjectResponse.Project != nil {
+		return jiraProjectResponse.Project
+	}
+	return nil
+}
+
+// Create a scrum board(rapid view) over the project's issues
+func (jiraService *JiraService) CreateRapidViewInJira(projectKey string,
+	name string) *communicator.GreenhopperRapidView {
+
+	var rapidViewRequest communicator.Request
+	rapidViewRequest.Project = projectKey
+	rapidViewRequest.RapidViewName = name
+	rapidViewResponse, err := utility.GetUtilitiesSingleton().JiraClient.CreateRapidView(
+		jiraService.getContext(), &rapidViewRequest)
+	if err == nil && rapidViewResponse.Error == nil && rapidViewResponse.RapidView != nil {
+		return rapidViewResponse.RapidView
+	}
+	return nil
+}
+
 func (jiraService *JiraService) CreateSprintInJira(rapidViewId string) *communicator.Sprint {
 	var created *communicator.Sprint
 	toCreate := communicator.SprintWithMeta{}
 	toCreate.RapidView = rapidViewId
 	jiraCreateSprintResponse, err := utility.GetUtilitiesSingleton().JiraClient.CreateSprint(
-		utility.GetUtilitiesSingleton().CommsContext, &toCreate)
+		jiraService.getContext(), &toCreate)
 	if err == nil && jiraCreateSprintResponse.Error == nil && jiraCreateSprintResponse.Sprint != nil {
 		created = jiraCreateSprintResponse.Sprint
 	}
@@ -59,7 +118,7 @@ func (jiraService *JiraService) CreateSprintInJira(rapidViewId string) *communic
 }
 func (jiraService *JiraService) CreateIssueInJira(issue *communicator.IssueCreate) *communicator.Issue {
 	jiraCreateIssueResponse, err := utility.GetUtilitiesSingleton().JiraClient.CreateIssue(
-		utility.GetUtilitiesSingleton().CommsContext, issue)
+		jiraService.getContext(), issue)
 	if err == nil && jiraCreateIssueResponse.Error == nil && jiraCreateIssueResponse.Issue != nil {
 		return jiraCreateIssueResponse.Issue
 	}
@@ -68,15 +127,18 @@ func (jiraService *JiraService) CreateIssueInJira(issue *communicator.IssueCreat
 func (jiraService *JiraService) CreateWorklogInJira(issueKey string, worklog *communicator.WorklogWithMeta) *communicator.Worklog {
 	jiraCreateWorklogRequest := new(communicator.Request)
 	jiraCreateWorklogRequest.Issue = issueKey
+	jiraCreateWorklogRequest.AdjustEstimate = utility.AdjustEstimateAuto
 	worklogCreate := new(communicator.WorklogCreate)
 	worklogCreate.TimeSpentSeconds = worklog.TimeSpentSeconds
 	worklogCreate.Comment = worklog.Comment
 	worklogCreate.Started = worklog.Started
 	worklogCreate.Author = new(communicator.WorklogCreateAuthor)
 	worklogCreate.Author.EmailAddress = worklog.Author.EmailAddress
+	worklogCreate.Author.Name = worklog.Author.Name
+	worklogCreate.Author.AccountId = worklog.Author.AccountId
 	jiraCreateWorklogRequest.Worklog = worklogCreate
 	jiraCreateWorklogResponse, err := utility.GetUtilitiesSingleton().JiraClient.CreateWorklog(
-		utility.GetUtilitiesSingleton().CommsContext, jiraCreateWorklogRequest)
+		jiraService.getContext(), jiraCreateWorklogRequest)
 	if err == nil && jiraCreateWorklogResponse.Error == nil && jiraCreateWorklogResponse.Worklog != nil {
 		return jiraCreateWorklogResponse.Worklog
 	}
@@ -88,32 +150,159 @@ func (jiraService *JiraService) UpdateWorklogInJira(issueKey string,
 	jiraUpdateWorklogRequest := new(communicator.Request)
 	jiraUpdateWorklogRequest.KeyOrId = worklog.Id
 	jiraUpdateWorklogRequest.Issue = issueKey
+	jiraUpdateWorklogRequest.AdjustEstimate = utility.AdjustEstimateAuto
 	worklogUpdate := new(communicator.WorklogCreate)
 	worklogUpdate.TimeSpentSeconds = worklog.TimeSpentSeconds
 	worklogUpdate.Comment = worklog.Comment
 	worklogUpdate.Started = worklog.Started
 	worklogUpdate.Author = new(communicator.WorklogCreateAuthor)
 	worklogUpdate.Author.EmailAddress = worklog.Author.EmailAddress
+	worklogUpdate.Author.Name = worklog.Author.Name
+	worklogUpdate.Author.AccountId = worklog.Author.AccountId
 	jiraUpdateWorklogRequest.Worklog = worklogUpdate
 	jiraUpdateWorklogResponse, err := utility.GetUtilitiesSingleton().JiraClient.UpdateWorklog(
-		utility.GetUtilitiesSingleton().CommsContext, jiraUpdateWorklogRequest)
+		jiraService.getContext(), jiraUpdateWorklogRequest)
 	if err == nil && jiraUpdateWorklogResponse.Error == nil && jiraUpdateWorklogResponse.Worklog != nil {
 		return jiraUpdateWorklogResponse.Worklog
 	}
 	return nil
 }
+func (jiraService *JiraService) DeleteWorklogInJira(issueKey string, worklogId string) bool {
+	jiraDeleteWorklogRequest := new(communicator.Request)
+	jiraDeleteWorklogRequest.KeyOrId = worklogId
+	jiraDeleteWorklogRequest.Issue = issueKey
+	jiraDeleteWorklogRequest.AdjustEstimate = utility.AdjustEstimateAuto
+	jiraDeleteWorklogResponse, err := utility.GetUtilitiesSingleton().JiraClient.DeleteWorklog(
+		jiraService.getContext(), jiraDeleteWorklogRequest)
+	if err == nil && jiraDeleteWorklogResponse.Error == nil {
+		return true
+	}
+	return false
+}
+func (jiraService *JiraService) CreateCommentInJira(issueKey string, body string) *communicator.Comment {
+	jiraCreateCommentRequest := new(communicator.Request)
+	jiraCreateCommentRequest.Issue = issueKey
+	jiraCreateCommentRequest.Comment = new(communicator.Comment)
+	jiraCreateCommentRequest.Comment.Body = body
+	jiraCreateCommentResponse, err := utility.GetUtilitiesSingleton().JiraClient.CreateComment(
+		jiraService.getContext(), jiraCreateCommentRequest)
+	if err == nil && jiraCreateCommentResponse.Error == nil && jiraCreateCommentResponse.Comment != nil {
+		return jiraCreateCommentResponse.Comment
+	}
+	return nil
+}
+func (jiraService *JiraService) UpdateCommentInJira(issueKey string, commentId string,
+	body string) *communicator.Comment {
+
+	jiraUpdateCommentRequest := new(communicator.Request)
+	jiraUpdateCommentRequest.KeyOrId = commentId
+	jiraUpdateCommentRequest.Issue = issueKey
+	jiraUpdateCommentRequest.Comment = new(communicator.Comment)
+	jiraUpdateCommentRequest.Comment.Body = body
+	jiraUpdateCommentResponse, err := utility.GetUtilitiesSingleton().JiraClient.UpdateComment(
+		jiraService.getContext(), jiraUpdateCommentRequest)
+	if err == nil && jiraUpdateCommentResponse.Error == nil && jiraUpdateCommentResponse.Comment != nil {
+		return jiraUpdateCommentResponse.Comment
+	}
+	return nil
+}
+
+// Stream an attachment to a JIRA issue in chunks, the file's name opening the upload & an empty chunk ending it
+func (jiraService *JiraService) UploadAttachmentToJira(issueKey string, fileName string,
+	content io.Reader) *communicator.Attachment {
+
+	stream, err := utility.GetUtilitiesSingleton().JiraClient.UploadAttachment(jiraService.getContext())
+	if err != nil {
+		return nil
+	}
+	defer stream.Close()
+	opening := &communicator.Request{Issue: issueKey, Attachment: &communicator.Attachment{Filename: fileName}}
+	if sendErr := stream.Send(opening); sendErr != nil {
+		return nil
+	}
+	chunk := make([]byte, utility.AttachmentChunkSize)
+	for {
+		read, readErr := content.Read(chunk)
+		if read > 0 {
+			sendErr := stream.Send(&communicator.Request{Attachment: &communicator.Attachment{Content: chunk[:read]}})
+			if sendErr != nil {
+				return nil
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil
+		}
+	}
+	if sendErr := stream.Send(&communicator.Request{Attachment: &communicator.Attachment{}}); sendErr != nil {
+		return nil
+	}
+	jiraAttachmentResponse, err := stream.Recv()
+	if err == nil && jiraAttachmentResponse.Error == nil && jiraAttachmentResponse.Attachment != nil {
+		return jiraAttachmentResponse.Attachment
+	}
+	return nil
+}
 func (jiraService *JiraService) UpdateIssueInJira(issue *communicator.IssueCreate) bool {
 	jiraUpdateIssueResponse, err := utility.GetUtilitiesSingleton().JiraClient.UpdateIssue(
-		utility.GetUtilitiesSingleton().CommsContext, issue)
+		jiraService.getContext(), issue)
 	if err == nil && jiraUpdateIssueResponse.Error == nil {
 		return true
 	}
 	return false
 }
 
+func (jiraService *JiraService) GetTransitionsForJiraIssue(issueKey string) []*communicator.Transition {
+	var transitionsRequest communicator.Request
+	transitionsRequest.Issue = issueKey
+	response, err := utility.GetUtilitiesSingleton().JiraClient.GetTransitions(
+		jiraService.getContext(), &transitionsRequest)
+	if nil != err || nil != response.Error {
+		return nil
+	}
+	return response.Transitions
+}
+
+func (jiraService *JiraService) TransitionJiraIssue(issueKey string, transitionId string) bool {
+	var transitionRequest communicator.Request
+	transitionRequest.Issue = issueKey
+	transitionRequest.Transition = transitionId
+	response, err := utility.GetUtilitiesSingleton().JiraClient.DoTransition(
+		jiraService.getContext(), &transitionRequest)
+	if nil != err || nil != response.Error {
+		return false
+	}
+	return true
+}
+
+func (jiraService *JiraService) GetAllowedIssueTypesForJiraIssue(issueKey string) []*communicator.IssueType {
+	var issueTypesRequest communicator.Request
+	issueTypesRequest.Issue = issueKey
+	response, err := utility.GetUtilitiesSingleton().JiraClient.GetAllowedIssueTypes(
+		jiraService.getContext(), &issueTypesRequest)
+	if nil != err || nil != response.Error {
+		return nil
+	}
+	return response.IssueTypes
+}
+
+func (jiraService *JiraService) ChangeIssueTypeInJira(issueKey string, issueTypeId string) bool {
+	var moveRequest communicator.Request
+	moveRequest.Issue = issueKey
+	moveRequest.IssueType = issueTypeId
+	response, err := utility.GetUtilitiesSingleton().JiraClient.MoveIssue(
+		jiraService.getContext(), &moveRequest)
+	if nil != err || nil != response.Error {
+		return false
+	}
+	return true
+}
+
 func (jiraService *JiraService) UpdateSprintInJira(sprint *communicator.SprintWithMeta) error {
 	jiraCreateSprintResponse, err := utility.GetUtilitiesSingleton().JiraClient.UpdateSprint(
-		utility.GetUtilitiesSingleton().CommsContext, sprint)
+		jiraService.getContext(), sprint)
 	if err != nil || jiraCreateSprintResponse.Error != nil || jiraCreateSprintResponse.Sprint == nil {
 		return errors.New("Failed to update sprint in JIRA")
 	}
@@ -126,7 +315,7 @@ func (jiraService *JiraService) DoesProjectExistInJira(projectId int32, exists c
 	var projectRequest communicator.Request
 	projectRequest.Project = fmt.Sprint(projectId)
 	jiraProjectResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetProject(
-		utility.GetUtilitiesSingleton().CommsContext, &projectRequest)
+		jiraService.getContext(), &projectRequest)
 	if err == nil && jiraProjectResponse.Error == nil &&
 		jiraProjectResponse != nil &&
 		jiraProjectResponse.Project != nil {
@@ -142,7 +331,7 @@ func (jiraService *JiraService) DoesEpicExistInJiraProject(epicKey string, exist
 
 	epicRequest.Epic = epicKey
 	jiraEpicResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetEpic(
-		utility.GetUtilitiesSingleton().CommsContext, &epicRequest)
+		jiraService.getContext(), &epicRequest)
 	if err == nil &&
 		jiraEpicResponse.Error == nil &&
 		jiraEpicResponse != nil &&
@@ -158,7 +347,7 @@ func (jiraService *JiraService) GetEpicInJiraProject(epicKey string) *communicat
 
 	epicRequest.Epic = epicKey
 	jiraEpicResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetEpic(
-		utility.GetUtilitiesSingleton().CommsContext, &epicRequest)
+		jiraService.getContext(), &epicRequest)
 	if err == nil &&
 		jiraEpicResponse.Error == nil &&
 		jiraEpicResponse != nil &&
@@ -168,6 +357,24 @@ func (jiraService *JiraService) GetEpicInJiraProject(epicKey string) *communicat
 	return nil
 }
 
+// Find an epic in JIRA, telling an epic that doesn't exist(no epic & no error) apart from a failed lookup
+func (jiraService *JiraService) FindEpicInJiraProject(epicKey string) (*communicator.Issue, error) {
+	var epicRequest communicator.Request
+	epicRequest.Epic = epicKey
+	jiraEpicResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetEpic(
+		jiraService.getContext(), &epicRequest)
+	if err != nil {
+		return nil, err
+	}
+	if jiraEpicResponse.Error != nil {
+		if jiraEpicResponse.Error.Code == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.New(fmt.Sprintf("Failed to retrieve epic %s", epicKey))
+	}
+	return jiraEpicResponse.Issue, nil
+}
+
 func (jiraService *JiraService) RetrieveRapidViewsInProject(projectKey string,
 	views chan []communicator.GreenhopperRapidView) {
 
@@ -176,7 +383,7 @@ func (jiraService *JiraService) RetrieveRapidViewsInProject(projectKey string,
 	var rapidViews []communicator.GreenhopperRapidView
 	rapidViewsRequest.Project = projectKey
 	rapidViewsResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetRapidViews(
-		utility.GetUtilitiesSingleton().CommsContext, &rapidViewsRequest)
+		jiraService.getContext(), &rapidViewsRequest)
 	if err == nil && rapidViewsResponse.Error == nil && rapidViewsResponse.RapidViews != nil {
 		for _, rapidView := range rapidViewsResponse.RapidViews {
 			rapidViews = append(rapidViews, *rapidView)
@@ -191,7 +398,7 @@ func (jiraService *JiraService) RetrieveSprintsInProject(projectKey string, spri
 	var jiraSprints []communicator.Sprint
 	sprintsRequest.Project = projectKey
 	jiraSprintsResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetSprints(
-		utility.GetUtilitiesSingleton().CommsContext, &sprintsRequest)
+		jiraService.getContext(), &sprintsRequest)
 	if err == nil && jiraSprintsResponse.Error == nil && jiraSprintsResponse.Sprints != nil {
 		for _, jiraSprint := range jiraSprintsResponse.Sprints {
 			jiraSprints = append(jiraSprints, *jiraSprint)
@@ -209,7 +416,42 @@ func (jiraService *JiraService) RetrieveIssuesFromSprintInProject(projectKey str
 	issuesRequest.Project = projectKey
 	issuesRequest.Sprint = sprintName
 	jiraIssuesResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetIssues(
-		utility.GetUtilitiesSingleton().CommsContext, &issuesRequest)
+		jiraService.getContext(), &issuesRequest)
+	if err == nil && jiraIssuesResponse.Error == nil && jiraIssuesResponse.Issues != nil &&
+		jiraIssuesResponse.Issues.Issues != nil {
+		for _, jiraIssue := range jiraIssuesResponse.Issues.Issues {
+			jiraIssues = append(jiraIssues, *jiraIssue)
+		}
+	}
+	issues <- jiraIssues
+}
+
+func (jiraService *JiraService) RetrieveEpicsInProject(projectKey string, epics chan []communicator.Issue) {
+	var jiraEpicsResponse *communicator.Response
+	var epicsRequest communicator.Request
+	var jiraEpics []communicator.Issue
+	epicsRequest.Project = projectKey
+	jiraEpicsResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetEpics(
+		jiraService.getContext(), &epicsRequest)
+	if err == nil && jiraEpicsResponse.Error == nil && jiraEpicsResponse.Issues != nil &&
+		jiraEpicsResponse.Issues.Issues != nil {
+		for _, jiraEpic := range jiraEpicsResponse.Issues.Issues {
+			jiraEpics = append(jiraEpics, *jiraEpic)
+		}
+	}
+	epics <- jiraEpics
+}
+
+func (jiraService *JiraService) RetrieveIssuesFromEpicInProject(projectKey string, epicKey string,
+	issues chan []communicator.Issue) {
+
+	var jiraIssuesResponse *communicator.Response
+	var issuesRequest communicator.Request
+	var jiraIssues []communicator.Issue
+	issuesRequest.Project = projectKey
+	issuesRequest.Epic = epicKey
+	jiraIssuesResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetIssuesInEpic(
+		jiraService.getContext(), &issuesRequest)
 	if err == nil && jiraIssuesResponse.Error == nil && jiraIssuesResponse.Issues != nil &&
 		jiraIssuesResponse.Issues.Issues != nil {
 		for _, jiraIssue := range jiraIssuesResponse.Issues.Issues {
@@ -225,19 +467,68 @@ func (jiraService *JiraService) RetrieveIssueInProject(projectKey string, issueI
 	issuesRequest.Project = projectKey
 	issuesRequest.Issue = issueId
 	jiraIssuesResponse, err := utility.GetUtilitiesSingleton().JiraClient.GetIssueById(
-		utility.GetUtilitiesSingleton().CommsContext, &issuesRequest)
+		jiraService.getContext(), &issuesRequest)
 	if err == nil && jiraIssuesResponse.Error == nil && jiraIssuesResponse.Issue != nil {
 		return jiraIssuesResponse.Issue
 	}
 	return nil
 }
 
+// Retrieve the issues of the project updated since the given time, worklogs logged on an issue updating it too
+func (jiraService *JiraService) RetrieveIssuesUpdatedSinceInProject(projectKey string,
+	updatedSince time.Time) ([]communicator.Issue, error) {
+
+	var issues []communicator.Issue
+	var searchRequest communicator.Request
+	// JQL reads absolute times in the timezone of the account the communicator acts as, unlike relative ones
+	minutesSince := int64(time.Since(updatedSince)/time.Minute) + 1
+	searchRequest.Jql = fmt.Sprintf(`project = "%s" AND updated >= "-%dm"`, projectKey, minutesSince)
+	searchResponse, err := utility.GetUtilitiesSingleton().JiraClient.SearchIssues(
+		jiraService.getContext(), &searchRequest)
+	if err != nil {
+		return issues, err
+	}
+	if searchResponse.Error != nil {
+		return issues, errors.New(fmt.Sprintf("Failed to retrieve issues of project %s updated since %v",
+			projectKey, updatedSince))
+	}
+	if searchResponse.Issues != nil {
+		for _, issue := range searchResponse.Issues.Issues {
+			issues = append(issues, *issue)
+		}
+	}
+	return issues, nil
+}
+
+func (jiraService *JiraService) RetrieveIssuesWithLabelInProject(projectKey string,
+	label string) ([]communicator.Issue, error) {
+
+	var issues []communicator.Issue
+	var searchRequest communicator.Request
+	searchRequest.Jql = fmt.Sprintf(`project = "%s" AND labels = "%s"`, projectKey, label)
+	searchResponse, err := utility.GetUtilitiesSingleton().JiraClient.SearchIssues(
+		jiraService.getContext(), &searchRequest)
+	if err != nil {
+		return issues, err
+	}
+	if searchResponse.Error != nil {
+		return issues, errors.New(fmt.Sprintf("Failed to retrieve issues of project %s labelled '%s'", projectKey,
+			label))
+	}
+	if searchResponse.Issues != nil {
+		for _, issue := range searchResponse.Issues.Issues {
+			issues = append(issues, *issue)
+		}
+	}
+	return issues, nil
+}
+
 func (jiraService *JiraService) UpdateSprintInfoForJiraIssue(sprintId string, issueKey string) bool {
 	var moveRequest communicator.Request
 	moveRequest.Sprint = sprintId
 	moveRequest.Issue = issueKey
 	response, err := utility.GetUtilitiesSingleton().JiraClient.MoveIssueToSprint(
-		utility.GetUtilitiesSingleton().CommsContext, &moveRequest)
+		jiraService.getContext(), &moveRequest)
 	if nil != err || nil != response.Error {
 		return false
 	}
@@ -249,7 +540,41 @@ func (jiraService *JiraService) UpdateEpicInfoForJiraIssue(epicKey string, issue
 	moveRequest.Epic = epicKey
 	moveRequest.Issue = issueKey
 	response, err := utility.GetUtilitiesSingleton().JiraClient.AddIssueToEpic(
-		utility.GetUtilitiesSingleton().CommsContext, &moveRequest)
+		jiraService.getContext(), &moveRequest)
+	if nil != err || nil != response.Error {
+		return false
+	}
+	return true
+}
+
+func (jiraService *JiraService) CloseIssueInJira(issueKey string) bool {
+	var closeRequest communicator.Request
+	closeRequest.Issue = issueKey
+	response, err := utility.GetUtilitiesSingleton().JiraClient.CloseIssue(
+		jiraService.getContext(), &closeRequest)
+	if nil != err || nil != response.Error {
+		return false
+	}
+	return true
+}
+
+func (jiraService *JiraService) DeleteIssueInJira(issueKey string) bool {
+	var deleteRequest communicator.Request
+	deleteRequest.Issue = issueKey
+	response, err := utility.GetUtilitiesSingleton().JiraClient.DeleteIssue(
+		jiraService.getContext(), &deleteRequest)
+	if nil != err || nil != response.Error {
+		return false
+	}
+	return true
+}
+
+func (jiraService *JiraService) AddLabelToJiraIssue(issueKey string, label string) bool {
+	var labelRequest communicator.Request
+	labelRequest.Issue = issueKey
+	labelRequest.Label = label
+	response, err := utility.GetUtilitiesSingleton().JiraClient.AddLabelToIssue(
+		jiraService.getContext(), &labelRequest)
 	if nil != err || nil != response.Error {
 		return false
 	}
@@ -261,7 +586,7 @@ func (jiraService *JiraService) GetJiraIssueIdFromProjectKeyAndIssueKey(projectK
 	issueRequest.Project = projectKey
 	issueRequest.Issue = issueKey
 	response, err := utility.GetUtilitiesSingleton().JiraClient.GetIssue(
-		utility.GetUtilitiesSingleton().CommsContext, &issueRequest)
+		jiraService.getContext(), &issueRequest)
 	if nil == err && nil == response.Error && nil != response.Issue {
 		issueId64, issueId64Err := strconv.ParseInt(response.Issue.Id, 10, 32)
 		if issueId64Err != nil {
@@ -277,7 +602,7 @@ func (jiraService *JiraService) GetWorklogsFromIssue(issueKey string, worklogs c
 	var issueRequest communicator.Issue
 	issueRequest.Key = issueKey
 	response, err := utility.GetUtilitiesSingleton().JiraClient.GetIssueWorklogs(
-		utility.GetUtilitiesSingleton().CommsContext, &issueRequest)
+		jiraService.getContext(), &issueRequest)
 	if nil == err && nil == response.Error && nil != response.Worklogs && nil != response.Worklogs.Worklogs {
 		for _, worklog := range response.Worklogs.Worklogs {
 			accumulatedWorklogs = append(accumulatedWorklogs, *worklog)
@@ -291,7 +616,7 @@ func (jiraService *JiraService) GetUsersInProject(projectName string, users chan
 	var request communicator.Request
 	request.Project = projectName
 	response, err := utility.GetUtilitiesSingleton().JiraClient.GetUsers(
-		utility.GetUtilitiesSingleton().CommsContext, &request)
+		jiraService.getContext(), &request)
 	if nil == err && nil == response.Error && nil != response.Authors && len(response.Authors) > 0 {
 		for _, author := range response.Authors {
 			availableUsers = append(availableUsers, *author)
@@ -305,7 +630,7 @@ func (jiraService *JiraService) GetJiraStatusMetadata(projectId string, statuses
 	var request communicator.Request
 	request.Project = projectId
 	response, err := utility.GetUtilitiesSingleton().JiraClient.GetIssueStatuses(
-		utility.GetUtilitiesSingleton().CommsContext, &request)
+		jiraService.getContext(), &request)
 	if nil == err && nil == response.Error && nil != response.Statuses {
 		for _, status := range response.Statuses {
 			availableStatuses = append(availableStatuses, *status)
@@ -319,7 +644,7 @@ func (jiraService *JiraService) GetJiraPriorityMetadata(projectId string, priori
 	var request communicator.Request
 	request.Project = projectId
 	response, err := utility.GetUtilitiesSingleton().JiraClient.GetIssuePriorities(
-		utility.GetUtilitiesSingleton().CommsContext, &request)
+		jiraService.getContext(), &request)
 	if nil == err && nil == response.Error && nil != response.Priorities {
 		for _, priority := range response.Priorities {
 			availablePriorities = append(availablePriorities, *priority)
@@ -3,18 +3,45 @@ package services
 import (
 	"fmt"
 	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/desertjinn/mavenlink-jira-sync/POGO"
 	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"io"
 	"strings"
 )
 
 type MavenlinkServiceInterface interface {
 	DoesWorkspaceExistInMavenlink(keyOrId int32, exists chan bool)
-	RetrieveTasksInWorkspaceWithTitle(keyOrId int32, tasks chan []communicator.Task, title string)
-	RetrieveSubTasksInWorkspace(keyOrId int32, taskKeyOrId int32, tasks chan []communicator.Task)
-	RetrieveTasksFromSubTasksInWorkspace(keyOrId int32, subTaskKeyOrId int32, tasks chan []communicator.Task)
-	GetTimeEntriesForIssueTask(workspaceKeyOrId int32, taskKeyOrId string, timeEntries chan []communicator.Timeentry)
+	GetWorkspaceInMavenlink(keyOrId int32) *communicator.Project
+	RetrieveTasksInWorkspaceWithTitleOrId(keyOrId int32, tasks chan []communicator.Task, titlesOrIds []string)
+	RetrieveSubTasksInWorkspace(keyOrId int32, taskKeyOrId int32, tasks chan POGO.SubTasksInMilestones)
+	RetrieveTasksFromSubTasksInWorkspace(keyOrId int32, subTaskKeyOrId int32, tasks chan POGO.TasksInSubTasks)
+	RetrieveTasksUpdatedSinceInWorkspace(keyOrId int32, updatedSince string) ([]communicator.Task, error)
+	GetTaskInMavenlink(workspaceKeyOrId int32, taskKeyOrId string) *communicator.Task
+	GetTimeEntriesForIssueTask(workspaceKeyOrId int32, taskKeyOrId string, timeEntries chan POGO.TaskTimeentries)
+	RetrieveTimeEntriesUpdatedSinceInWorkspace(keyOrId int32, updatedSince string) ([]communicator.Timeentry, error)
+	GetTimeentryInMavenlink(workspaceKeyOrId int32, timeentryKeyOrId string) *communicator.Timeentry
+	GetUsersInWorkspace(keyOrId int32, users chan []communicator.User)
+	UpdateTaskInMavenlink(task *communicator.Task) bool
+	CreateTimeentryInMavenlink(workspaceKeyOrId int32, timeentry *communicator.Timeentry) *communicator.Timeentry
+	GetPostsForIssueTask(workspaceKeyOrId int32, taskKeyOrId string, posts chan []communicator.Post)
+	GetAttachmentsForIssueTask(workspaceKeyOrId int32, taskKeyOrId string,
+		attachments chan []communicator.Attachment)
+	DownloadAttachmentFromMavenlink(attachmentKeyOrId string, maxSize int64) (io.ReadCloser, error)
+}
+type MavenlinkService struct {
+	// Context of the run the service is bound to
+	Context context.Context
+}
+
+// Get the context of the run the service is bound to, the process' one when it isn't bound to a run
+func (mavenlinkService *MavenlinkService) getContext() context.Context {
+	if mavenlinkService.Context != nil {
+		return mavenlinkService.Context
+	}
+	return utility.GetUtilitiesSingleton().CommsContext
 }
-type MavenlinkService struct {}
 
 // Check if the workspace exists in Mavenlink
 func (mavenlinkService *MavenlinkService) DoesWorkspaceExistInMavenlink(keyOrId int32, exists chan bool) {
@@ -23,7 +50,7 @@ func (mavenlinkService *MavenlinkService) DoesWorkspaceExistInMavenlink(keyOrId
 	var projectExistsRequest communicator.Request
 	projectExistsRequest.Workspace = fmt.Sprint(keyOrId)
 	mavenlinkProjectsResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetProjectById(
-		utility.GetUtilitiesSingleton().CommsContext, &projectExistsRequest)
+		mavenlinkService.getContext(), &projectExistsRequest)
 	if err == nil && mavenlinkProjectsResponse.Error == nil &&
 		mavenlinkProjectsResponse != nil &&
 		mavenlinkProjectsResponse.Project != nil {
@@ -32,22 +59,37 @@ func (mavenlinkService *MavenlinkService) DoesWorkspaceExistInMavenlink(keyOrId
 	exists <- does
 }
 
-// Retrieve the milestone task with desired title from the workspace in Mavenlink
-func (mavenlinkService *MavenlinkService) RetrieveTasksInWorkspaceWithTitle(keyOrId int32,
-	tasks chan []communicator.Task, title string) {
+func (mavenlinkService *MavenlinkService) GetWorkspaceInMavenlink(keyOrId int32) *communicator.Project {
+	var mavenlinkProjectsResponse *communicator.Response
+	var projectRequest communicator.Request
+	projectRequest.Workspace = fmt.Sprint(keyOrId)
+	mavenlinkProjectsResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetProjectById(
+		mavenlinkService.getContext(), &projectRequest)
+	if err == nil && mavenlinkProjectsResponse.Error == nil && mavenlinkProjectsResponse.Project != nil {
+		return mavenlinkProjectsResponse.Project
+	}
+	return nil
+}
+
+// Retrieve the milestone tasks with any of the desired titles or IDs from the workspace in Mavenlink
+func (mavenlinkService *MavenlinkService) RetrieveTasksInWorkspaceWithTitleOrId(keyOrId int32,
+	tasks chan []communicator.Task, titlesOrIds []string) {
 
 	var mavenlinkTasksResponse *communicator.Response
 	var mavenlinkTasks []communicator.Task
 	var taskListRequest communicator.Request
 	taskListRequest.Workspace = fmt.Sprint(keyOrId)
 	mavenlinkTasksResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetTasksByProjectId(
-		utility.GetUtilitiesSingleton().CommsContext, &taskListRequest)
+		mavenlinkService.getContext(), &taskListRequest)
 	if err == nil && mavenlinkTasksResponse.Error == nil &&
 		mavenlinkTasksResponse != nil &&
 		mavenlinkTasksResponse.Tasks != nil {
 		for _, task := range mavenlinkTasksResponse.Tasks {
-			if strings.EqualFold(task.Title, title) {
-				mavenlinkTasks = append(mavenlinkTasks, *task)
+			for _, titleOrId := range titlesOrIds {
+				if strings.EqualFold(task.Title, titleOrId) || task.Id == titleOrId {
+					mavenlinkTasks = append(mavenlinkTasks, *task)
+					break
+				}
 			}
 		}
 	}
@@ -56,61 +98,271 @@ func (mavenlinkService *MavenlinkService) RetrieveTasksInWorkspaceWithTitle(keyO
 
 // Retrieve the all sub-tasks in milestone task from the workspace in Mavenlink
 func (mavenlinkService *MavenlinkService) RetrieveSubTasksInWorkspace(keyOrId int32, taskKeyOrId int32,
-	tasks chan []communicator.Task) {
+	tasks chan POGO.SubTasksInMilestones) {
 
 	var subTasksResponse *communicator.Response
 	var taskListRequest communicator.Request
-	var subTasks []communicator.Task
+	var subTasks POGO.SubTasksInMilestones
 	taskListRequest.Workspace = fmt.Sprint(keyOrId)
 	taskListRequest.Task = fmt.Sprint(taskKeyOrId)
 	subTasksResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetSubTasksByParentTaskAndProjectId(
-		utility.GetUtilitiesSingleton().CommsContext, &taskListRequest)
-	if err == nil && subTasksResponse.Error == nil &&
-		subTasksResponse != nil &&
-		subTasksResponse.Tasks != nil {
+		mavenlinkService.getContext(), &taskListRequest)
+	if err == nil && subTasksResponse.Error == nil {
 		for _, subTask := range subTasksResponse.Tasks {
-			subTasks = append(subTasks, *subTask)
+			subTasks.SubTasks = append(subTasks.SubTasks, *subTask)
 		}
+	} else {
+		subTasks.FailedMilestoneIds = append(subTasks.FailedMilestoneIds, fmt.Sprint(taskKeyOrId))
 	}
 	tasks <- subTasks
 }
 
 // Retrieve the all the tasks in sub-tasks from the workspace in Mavenlink
 func (mavenlinkService *MavenlinkService) RetrieveTasksFromSubTasksInWorkspace(keyOrId int32, subTaskKeyOrId int32,
-	tasks chan []communicator.Task) {
+	tasks chan POGO.TasksInSubTasks) {
 	var tasksInSubTasksResponse *communicator.Response
 	var tasksInSubTaskListRequest communicator.Request
-	var tasksInSubTask []communicator.Task
+	var tasksInSubTask POGO.TasksInSubTasks
 	tasksInSubTaskListRequest.Workspace = fmt.Sprint(keyOrId)
 	tasksInSubTaskListRequest.SubTask = fmt.Sprint(subTaskKeyOrId)
 	tasksInSubTasksResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetTasksBySubTaskParentTaskAndProjectId(
-		utility.GetUtilitiesSingleton().CommsContext, &tasksInSubTaskListRequest)
-	if err == nil && tasksInSubTasksResponse.Error == nil &&
-		tasksInSubTasksResponse != nil &&
-		tasksInSubTasksResponse.Tasks != nil {
+		mavenlinkService.getContext(), &tasksInSubTaskListRequest)
+	if err == nil && tasksInSubTasksResponse.Error == nil {
 		for _, taskInSubTask := range tasksInSubTasksResponse.Tasks {
-			tasksInSubTask = append(tasksInSubTask, *taskInSubTask)
+			tasksInSubTask.Tasks = append(tasksInSubTask.Tasks, *taskInSubTask)
 		}
+	} else {
+		tasksInSubTask.FailedSubTaskIds = append(tasksInSubTask.FailedSubTaskIds, fmt.Sprint(subTaskKeyOrId))
 	}
 	tasks <- tasksInSubTask
 }
 
+// Retrieve every task of the workspace changed since the time(RFC 3339) in Mavenlink, failing rather than returning
+// no tasks when they can't be retrieved
+func (mavenlinkService *MavenlinkService) RetrieveTasksUpdatedSinceInWorkspace(keyOrId int32,
+	updatedSince string) ([]communicator.Task, error) {
+
+	var tasks []communicator.Task
+	var taskListRequest communicator.Request
+	taskListRequest.Workspace = fmt.Sprint(keyOrId)
+	taskListRequest.UpdatedAfter = updatedSince
+	tasksResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetTasksByProjectId(
+		mavenlinkService.getContext(), &taskListRequest)
+	if err != nil {
+		return tasks, err
+	}
+	if tasksResponse.Error != nil {
+		return tasks, errors.New(fmt.Sprintf("Failed to retrieve tasks of workspace %d updated since %s", keyOrId,
+			updatedSince))
+	}
+	for _, task := range tasksResponse.Tasks {
+		tasks = append(tasks, *task)
+	}
+	return tasks, nil
+}
+
+// Retrieve a single task of the workspace in Mavenlink
+func (mavenlinkService *MavenlinkService) GetTaskInMavenlink(workspaceKeyOrId int32,
+	taskKeyOrId string) *communicator.Task {
+
+	var taskRequest communicator.Request
+	taskRequest.Workspace = fmt.Sprint(workspaceKeyOrId)
+	taskRequest.Task = taskKeyOrId
+	taskResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetTaskById(
+		mavenlinkService.getContext(), &taskRequest)
+	if err == nil && taskResponse.Error == nil && taskResponse.Task != nil {
+		return taskResponse.Task
+	}
+	return nil
+}
+
+// Retrieve the time entries of a task in Mavenlink, along with the failure to retrieve them
 func (mavenlinkService *MavenlinkService) GetTimeEntriesForIssueTask(workspaceKeyOrId int32, taskKeyOrId string,
-	timeEntries chan []communicator.Timeentry) {
+	timeEntries chan POGO.TaskTimeentries) {
 
 	var timeentriesResponse *communicator.Response
 	var timeentriesRequest communicator.Request
-	var accumulatedTimeentries []communicator.Timeentry
+	accumulatedTimeentries := POGO.TaskTimeentries{TaskId: taskKeyOrId}
 	timeentriesRequest.Workspace = fmt.Sprint(workspaceKeyOrId)
 	timeentriesRequest.Task = taskKeyOrId
 	timeentriesResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetTimeentries(
-		utility.GetUtilitiesSingleton().CommsContext, &timeentriesRequest)
-	if err == nil && timeentriesResponse.Error == nil &&
-		timeentriesResponse != nil &&
-		timeentriesResponse.Timeentries != nil {
+		mavenlinkService.getContext(), &timeentriesRequest)
+	if err != nil {
+		accumulatedTimeentries.Err = err
+	} else if timeentriesResponse.Error != nil {
+		accumulatedTimeentries.Err = errors.New(fmt.Sprintf("Failed to retrieve time entries of task %s",
+			taskKeyOrId))
+	} else {
 		for _, timeentry := range timeentriesResponse.Timeentries {
-			accumulatedTimeentries = append(accumulatedTimeentries, *timeentry)
+			accumulatedTimeentries.Timeentries = append(accumulatedTimeentries.Timeentries, *timeentry)
 		}
 	}
 	timeEntries <- accumulatedTimeentries
 }
+
+// Retrieve every time entry of the workspace changed since the time(RFC 3339) in Mavenlink, failing rather than
+// returning no time entries when they can't be retrieved
+func (mavenlinkService *MavenlinkService) RetrieveTimeEntriesUpdatedSinceInWorkspace(keyOrId int32,
+	updatedSince string) ([]communicator.Timeentry, error) {
+
+	var timeentries []communicator.Timeentry
+	var timeentriesRequest communicator.Request
+	timeentriesRequest.Workspace = fmt.Sprint(keyOrId)
+	timeentriesRequest.UpdatedAfter = updatedSince
+	timeentriesResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetTimeentries(
+		mavenlinkService.getContext(), &timeentriesRequest)
+	if err != nil {
+		return timeentries, err
+	}
+	if timeentriesResponse.Error != nil {
+		return timeentries, errors.New(fmt.Sprintf("Failed to retrieve time entries of workspace %d updated since %s",
+			keyOrId, updatedSince))
+	}
+	for _, timeentry := range timeentriesResponse.Timeentries {
+		timeentries = append(timeentries, *timeentry)
+	}
+	return timeentries, nil
+}
+
+// Retrieve a single time entry of the workspace in Mavenlink
+func (mavenlinkService *MavenlinkService) GetTimeentryInMavenlink(workspaceKeyOrId int32,
+	timeentryKeyOrId string) *communicator.Timeentry {
+
+	var timeentryRequest communicator.Request
+	timeentryRequest.Workspace = fmt.Sprint(workspaceKeyOrId)
+	timeentryRequest.Timeentry = &communicator.Timeentry{Id: timeentryKeyOrId}
+	timeentryResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetTimeentryById(
+		mavenlinkService.getContext(), &timeentryRequest)
+	if err == nil && timeentryResponse.Error == nil && timeentryResponse.Timeentry != nil {
+		return timeentryResponse.Timeentry
+	}
+	return nil
+}
+
+// Retrieve the participants of the workspace in Mavenlink
+func (mavenlinkService *MavenlinkService) GetUsersInWorkspace(keyOrId int32, users chan []communicator.User) {
+	var usersResponse *communicator.Response
+	var usersRequest communicator.Request
+	var workspaceUsers []communicator.User
+	usersRequest.Workspace = fmt.Sprint(keyOrId)
+	usersResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetUsersByProjectId(
+		mavenlinkService.getContext(), &usersRequest)
+	if err == nil && usersResponse.Error == nil &&
+		usersResponse != nil &&
+		usersResponse.Users != nil {
+		for _, user := range usersResponse.Users {
+			workspaceUsers = append(workspaceUsers, *user)
+		}
+	}
+	users <- workspaceUsers
+}
+
+// Update the state and assignee of a task in Mavenlink
+func (mavenlinkService *MavenlinkService) UpdateTaskInMavenlink(task *communicator.Task) bool {
+	updateTaskResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.UpdateTask(
+		mavenlinkService.getContext(), task)
+	if err == nil && updateTaskResponse.Error == nil {
+		return true
+	}
+	return false
+}
+
+// Create a time entry against a task of the workspace in Mavenlink
+func (mavenlinkService *MavenlinkService) CreateTimeentryInMavenlink(workspaceKeyOrId int32,
+	timeentry *communicator.Timeentry) *communicator.Timeentry {
+
+	var timeentryRequest communicator.Request
+	timeentryRequest.Workspace = fmt.Sprint(workspaceKeyOrId)
+	timeentryRequest.Timeentry = timeentry
+	timeentryResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.CreateTimeentry(
+		mavenlinkService.getContext(), &timeentryRequest)
+	if err == nil && timeentryResponse.Error == nil && timeentryResponse.Timeentry != nil {
+		return timeentryResponse.Timeentry
+	}
+	return nil
+}
+
+func (mavenlinkService *MavenlinkService) GetPostsForIssueTask(workspaceKeyOrId int32, taskKeyOrId string,
+	posts chan []communicator.Post) {
+
+	var postsResponse *communicator.Response
+	var postsRequest communicator.Request
+	var accumulatedPosts []communicator.Post
+	postsRequest.Workspace = fmt.Sprint(workspaceKeyOrId)
+	postsRequest.Task = taskKeyOrId
+	postsResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetPosts(
+		mavenlinkService.getContext(), &postsRequest)
+	if err == nil && postsResponse.Error == nil &&
+		postsResponse != nil &&
+		postsResponse.Posts != nil {
+		for _, post := range postsResponse.Posts {
+			accumulatedPosts = append(accumulatedPosts, *post)
+		}
+	}
+	posts <- accumulatedPosts
+}
+
+func (mavenlinkService *MavenlinkService) GetAttachmentsForIssueTask(workspaceKeyOrId int32, taskKeyOrId string,
+	attachments chan []communicator.Attachment) {
+
+	var attachmentsResponse *communicator.Response
+	var attachmentsRequest communicator.Request
+	var accumulatedAttachments []communicator.Attachment
+	attachmentsRequest.Workspace = fmt.Sprint(workspaceKeyOrId)
+	attachmentsRequest.Task = taskKeyOrId
+	attachmentsResponse, err := utility.GetUtilitiesSingleton().MavenlinkClient.GetAttachments(
+		mavenlinkService.getContext(), &attachmentsRequest)
+	if err == nil && attachmentsResponse.Error == nil &&
+		attachmentsResponse != nil &&
+		attachmentsResponse.Attachments != nil {
+		for _, attachment := range attachmentsResponse.Attachments {
+			accumulatedAttachments = append(accumulatedAttachments, *attachment)
+		}
+	}
+	attachments <- accumulatedAttachments
+}
+
+// Content of a Mavenlink attachment, read chunk by chunk as it streams in
+type attachmentReader struct {
+	attachmentKeyOrId string
+	stream            communicator.MavenlinkCommunicator_DownloadAttachmentService
+	pending           []byte
+	size              int64
+	maxSize           int64
+}
+
+func (reader *attachmentReader) Read(buffer []byte) (int, error) {
+	for len(reader.pending) == 0 {
+		chunk, chunkErr := reader.stream.Recv()
+		if chunkErr != nil {
+			return 0, chunkErr
+		}
+		reader.pending = chunk.Data
+		reader.size += int64(len(chunk.Data))
+		if reader.size > reader.maxSize {
+			return 0, errors.New(fmt.Sprintf("Attachment(ID: %s) is larger than %d bytes",
+				reader.attachmentKeyOrId, reader.maxSize))
+		}
+	}
+	read := copy(buffer, reader.pending)
+	reader.pending = reader.pending[read:]
+	return read, nil
+}
+
+func (reader *attachmentReader) Close() error {
+	return reader.stream.Close()
+}
+
+// Stream the content of an attachment from Mavenlink, the reader failing once it grows beyond the size limit
+func (mavenlinkService *MavenlinkService) DownloadAttachmentFromMavenlink(attachmentKeyOrId string,
+	maxSize int64) (io.ReadCloser, error) {
+
+	var attachmentRequest communicator.Request
+	attachmentRequest.Attachment = attachmentKeyOrId
+	stream, err := utility.GetUtilitiesSingleton().MavenlinkClient.DownloadAttachment(
+		mavenlinkService.getContext(), &attachmentRequest)
+	if err != nil {
+		return nil, err
+	}
+	return &attachmentReader{attachmentKeyOrId: attachmentKeyOrId, stream: stream, maxSize: maxSize}, nil
+}
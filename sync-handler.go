@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	datasourceCommunicator "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
+	synchronizer "github.com/desertjinn/mavenlink-jira-sync/proto/mavenlink-jira-sync"
+	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	microErrors "github.com/micro/go-micro/errors"
+	"golang.org/x/net/context"
+)
+
+// Handles the synchronizer's gRPC service, running syncs through the serve subcommand's scheduler so a
+// triggered sync never overlaps a scheduled one
+type SyncHandler struct {
+	syncOps   *SyncOperations
+	scheduler *Scheduler
+}
+
+// Get the sync configuration requested of the method
+func (handler *SyncHandler) getSyncConfiguration(method string,
+	externalProjectId int32) (*datasourceCommunicator.ExternalProject, error) {
+
+	syncConfiguration := handler.scheduler.getSyncConfiguration(externalProjectId)
+	if syncConfiguration == nil {
+		return nil, microErrors.NotFound(utility.SyncService+"."+method,
+			"No sync configuration found for external project(ID: %d)", externalProjectId)
+	}
+	return syncConfiguration, nil
+}
+
+// Start syncing a project, responding with its run without waiting for it to finish
+func (handler *SyncHandler) TriggerSync(ctx context.Context, request *synchronizer.SyncRequest,
+	response *synchronizer.Run) error {
+
+	syncConfiguration, syncConfigurationErr := handler.getSyncConfiguration("TriggerSync",
+		request.ExternalProjectId)
+	if syncConfigurationErr != nil {
+		return syncConfigurationErr
+	}
+	run, runErr := handler.scheduler.TriggerProject(syncConfiguration)
+	if runErr != nil {
+		return microErrors.Conflict(utility.SyncService+".TriggerSync", runErr.Error())
+	}
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.CircularBulletPoint,
+		fmt.Sprintf("Triggered run %s of '%s'", run.Id, run.ProjectName))
+	*response = *run
+	return nil
+}
+
+func (handler *SyncHandler) GetRunStatus(ctx context.Context, request *synchronizer.RunRequest,
+	response *synchronizer.Run) error {
+
+	run := handler.scheduler.GetRun(request.RunId)
+	if run == nil {
+		return microErrors.NotFound(utility.SyncService+".GetRunStatus", "Run %s not found", request.RunId)
+	}
+	*response = *run
+	return nil
+}
+
+// List the kept runs of a project, or of every project when no project is requested
+func (handler *SyncHandler) ListRuns(ctx context.Context, request *synchronizer.RunsRequest,
+	response *synchronizer.RunsResponse) error {
+
+	response.Runs = handler.scheduler.GetRuns(request.ExternalProjectId)
+	return nil
+}
+
+// Stream the events of a run as it moves through the sync's phases, ending once it finishes
+func (handler *SyncHandler) WatchRun(ctx context.Context, request *synchronizer.RunRequest,
+	stream synchronizer.MavenlinkJiraSync_WatchRunStream) error {
+
+	defer stream.Close()
+	if handler.scheduler.GetRun(request.RunId) == nil {
+		return microErrors.NotFound(utility.SyncService+".WatchRun", "Run %s not found", request.RunId)
+	}
+	return handler.scheduler.WatchRun(ctx, request.RunId, stream.Send)
+}
+
+// Check a project's configuration as it's stored, never provisioning its epic as a run would
+func (handler *SyncHandler) ValidateConfiguration(ctx context.Context, request *synchronizer.SyncRequest,
+	response *synchronizer.ValidationResponse) error {
+
+	syncConfiguration, syncConfigurationErr := handler.getSyncConfiguration("ValidateConfiguration",
+		request.ExternalProjectId)
+	if syncConfigurationErr != nil {
+		return syncConfigurationErr
+	}
+	response.ExternalProjectId = syncConfiguration.Id
+	response.Valid = handler.syncOps.IsAValidSyncConfiguration(syncConfiguration)
+	return nil
+}
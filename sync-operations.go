@@ -7,38 +7,100 @@ import (
 	datasourceCommunicator "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
 	"github.com/desertjinn/mavenlink-jira-sync/POGO"
 	"github.com/desertjinn/mavenlink-jira-sync/functions"
+	synchronizer "github.com/desertjinn/mavenlink-jira-sync/proto/mavenlink-jira-sync"
 	"github.com/desertjinn/mavenlink-jira-sync/services"
 	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"io"
+	"io/ioutil"
+	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type SyncOperationsInterface interface {
 	IsAValidSyncConfiguration(syncConfiguration *datasourceCommunicator.ExternalProject) bool
+	ProvisionEpicIfRequired(syncConfiguration *datasourceCommunicator.ExternalProject) error
 	SyncMavenlinkToJira(externalProject *datasourceCommunicator.ExternalProject, success chan bool)
+	SyncMavenlinkTaskToJira(externalProject *datasourceCommunicator.ExternalProject, taskId string,
+		success chan bool)
 }
 type SyncOperations struct {
-	common     functions.CommonFunctionsInterface
-	worklog    functions.WorklogFunctionsInterface
-	issue      functions.IssueFunctionsInterface
-	sprint     functions.SprintFunctionsInterface
-	jira       services.JiraServiceInterface
-	mavenlink  services.MavenlinkServiceInterface
-	datasource services.DataSourceServiceInterface
+	environment *synchronizer.EnvironmentConfiguration
+	common      functions.CommonFunctionsInterface
+	worklog     functions.WorklogFunctionsInterface
+	issue       functions.IssueFunctionsInterface
+	sprint      functions.SprintFunctionsInterface
+	epic        functions.EpicFunctionsInterface
+	task        functions.TaskFunctionsInterface
+	comment     functions.CommentFunctionsInterface
+	attachment  functions.AttachmentFunctionsInterface
+	transition  functions.TransitionFunctionsInterface
+	identity    functions.IdentityFunctionsInterface
+	jira        services.JiraServiceInterface
+	mavenlink   services.MavenlinkServiceInterface
+	datasource  services.DataSourceServiceInterface
+	progress    ProgressReporter
+}
+
+// Get a copy of the operations whose calls are made in the given context, binding them to a single run
+func (syncOps *SyncOperations) WithContext(runContext context.Context) *SyncOperations {
+	bound := *syncOps
+	bound.worklog = &functions.WorklogFunctions{Context: runContext}
+	bound.issue = &functions.IssueFunctions{Context: runContext}
+	bound.sprint = &functions.SprintFunctions{Context: runContext}
+	bound.epic = &functions.EpicFunctions{Context: runContext}
+	bound.task = &functions.TaskFunctions{Context: runContext}
+	bound.comment = &functions.CommentFunctions{Context: runContext}
+	bound.attachment = &functions.AttachmentFunctions{Context: runContext}
+	bound.jira = &services.JiraService{Context: runContext}
+	bound.mavenlink = &services.MavenlinkService{Context: runContext}
+	bound.datasource = &services.DataSourceService{Context: runContext}
+	return &bound
+}
+
+// Check if JIRA is the master for changes made on both platforms
+func (syncOps *SyncOperations) isJiraMaster() bool {
+	return syncOps.environment != nil && strings.EqualFold(syncOps.environment.Master, utility.MasterJira)
+}
+
+// Load the project's equivalence relations from the datasource over the global default
+func (syncOps *SyncOperations) loadProjectEquivalence(externalProjectId int32) {
+	relations := utility.GetUtilitiesSingleton().MavenlinkToJiraEquivalence
+	defaultRelations, defaultRelationsErr := syncOps.datasource.GetEquivalenceConfiguration(0)
+	if defaultRelationsErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+			fmt.Sprintf("Failed to retrieve the default equivalences, using built-in ones: %v", defaultRelationsErr))
+	} else {
+		relations = utility.MergeMavenlinkToJiraEquivalence(relations, defaultRelations)
+	}
+	projectRelations, projectRelationsErr := syncOps.datasource.GetEquivalenceConfiguration(externalProjectId)
+	if projectRelationsErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+			fmt.Sprintf("Failed to retrieve the project's equivalences, using the defaults: %v", projectRelationsErr))
+	} else {
+		relations = utility.MergeMavenlinkToJiraEquivalence(relations, projectRelations)
+	}
+	utility.GetUtilitiesSingleton().SetProjectEquivalence(externalProjectId, relations)
 }
 
 func (syncOps *SyncOperations) retrieveAndCollateMavenlinkTasksInSubTasks(sync *datasourceCommunicator.ExternalProject,
-	subTasks []*mavenlinkCommunicator.Task, tasks chan []mavenlinkCommunicator.Task) {
+	subTasks []*mavenlinkCommunicator.Task, tasks chan POGO.TasksInSubTasks) {
 
-	var allTasks []mavenlinkCommunicator.Task
+	var allTasks POGO.TasksInSubTasks
 	if nil == sync || nil == subTasks {
 		tasks <- allTasks
+		return
 	}
-	tasksInSubTasks := make(chan []mavenlinkCommunicator.Task)
+	tasksInSubTasks := make(chan POGO.TasksInSubTasks)
 	if len(subTasks) > 0 {
 		processedSubTask := 0
 		for _, subTask := range subTasks {
 			subTaskIdInt64, subTaskIdInt64Err := strconv.ParseInt(subTask.Id, 10, 32)
 			if subTaskIdInt64Err != nil {
+				allTasks.FailedSubTaskIds = append(allTasks.FailedSubTaskIds, subTask.Id)
 				continue
 			}
 			go syncOps.mavenlink.RetrieveTasksFromSubTasksInWorkspace(sync.Source2ProjectId,
@@ -47,18 +109,52 @@ func (syncOps *SyncOperations) retrieveAndCollateMavenlinkTasksInSubTasks(sync *
 		}
 		for i := 0; i < processedSubTask; i++ {
 			currentTasks := <-tasksInSubTasks
-			allTasks = append(allTasks, currentTasks...)
+			allTasks.Tasks = append(allTasks.Tasks, currentTasks.Tasks...)
+			allTasks.FailedSubTaskIds = append(allTasks.FailedSubTaskIds, currentTasks.FailedSubTaskIds...)
 		}
 	}
 	tasks <- allTasks
 }
 
+func (syncOps *SyncOperations) retrieveAndCollateMavenlinkSubTasksInMilestones(
+	sync *datasourceCommunicator.ExternalProject, milestones []*mavenlinkCommunicator.Task,
+	subTasks chan POGO.SubTasksInMilestones) {
+
+	var allSubTasks POGO.SubTasksInMilestones
+	if nil == sync || nil == milestones {
+		subTasks <- allSubTasks
+		return
+	}
+	subTasksInMilestones := make(chan POGO.SubTasksInMilestones)
+	if len(milestones) > 0 {
+		processedMilestone := 0
+		for _, milestone := range milestones {
+			milestoneIdInt64, milestoneIdInt64Err := strconv.ParseInt(milestone.Id, 10, 32)
+			if milestoneIdInt64Err != nil {
+				allSubTasks.FailedMilestoneIds = append(allSubTasks.FailedMilestoneIds, milestone.Id)
+				continue
+			}
+			go syncOps.mavenlink.RetrieveSubTasksInWorkspace(sync.Source2ProjectId,
+				int32(milestoneIdInt64), subTasksInMilestones)
+			processedMilestone++
+		}
+		for i := 0; i < processedMilestone; i++ {
+			currentSubTasks := <-subTasksInMilestones
+			allSubTasks.SubTasks = append(allSubTasks.SubTasks, currentSubTasks.SubTasks...)
+			allSubTasks.FailedMilestoneIds = append(allSubTasks.FailedMilestoneIds,
+				currentSubTasks.FailedMilestoneIds...)
+		}
+	}
+	subTasks <- allSubTasks
+}
+
 func (syncOps *SyncOperations) retrieveAndCollateJiraTasksInSprints(jiraProject *jiraCommunicator.Project,
 	sprints []*jiraCommunicator.Sprint, issues chan []jiraCommunicator.Issue) {
 
 	var allIssues []jiraCommunicator.Issue
 	if nil == jiraProject || nil == sprints {
 		issues <- allIssues
+		return
 	}
 	issuesInSprint := make(chan []jiraCommunicator.Issue)
 	if len(sprints) > 0 {
@@ -75,13 +171,44 @@ func (syncOps *SyncOperations) retrieveAndCollateJiraTasksInSprints(jiraProject
 	issues <- allIssues
 }
 
+func (syncOps *SyncOperations) retrieveAndCollateJiraTasksInEpics(jiraProject *jiraCommunicator.Project,
+	epics []*jiraCommunicator.Issue, issues chan []jiraCommunicator.Issue) {
+
+	var allIssues []jiraCommunicator.Issue
+	if nil == jiraProject || nil == epics {
+		issues <- allIssues
+		return
+	}
+	issuesInEpic := make(chan []jiraCommunicator.Issue)
+	for _, epic := range epics {
+		go syncOps.jira.RetrieveIssuesFromEpicInProject(jiraProject.Key, epic.Key, issuesInEpic)
+	}
+	for i := 0; i < len(epics); i++ {
+		allIssues = append(allIssues, <-issuesInEpic...)
+	}
+	issues <- allIssues
+}
+
+// Update the sprint in JIRA, starting it first when it is to be completed as JIRA only completes active sprints
+func (syncOps *SyncOperations) updateSprintInJira(sprint *jiraCommunicator.SprintWithMeta) error {
+	if strings.EqualFold(sprint.State, utility.SprintStateClosed) {
+		activeSprint := *sprint
+		activeSprint.State = utility.SprintStateActive
+		activateErr := syncOps.jira.UpdateSprintInJira(&activeSprint)
+		if activateErr != nil {
+			return activateErr
+		}
+	}
+	return syncOps.jira.UpdateSprintInJira(sprint)
+}
+
 func (syncOps *SyncOperations) createSprint(externalProjectId int32, sprint jiraCommunicator.SprintWithMeta,
 	created chan bool) {
 
 	justCreated := syncOps.jira.CreateSprintInJira(sprint.RapidView)
 	if justCreated != nil {
 		sprint.Id = justCreated.Id
-		updateErr := syncOps.jira.UpdateSprintInJira(&sprint)
+		updateErr := syncOps.updateSprintInJira(&sprint)
 		if updateErr == nil {
 			saved := syncOps.datasource.SaveSprintAndTaskSyncHistory(externalProjectId, &sprint)
 			if saved == true {
@@ -94,15 +221,21 @@ func (syncOps *SyncOperations) createSprint(externalProjectId int32, sprint jira
 					"FAILED to save sync history")
 			}
 		}
+		syncOps.reportItem(externalProjectId, RunEventCreated, RunPhaseSprints, RunItemSprint, fmt.Sprint(sprint.Id),
+			sprint.Name)
 		created <- true
 	} else {
 		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
 			"FAILED to create sprint")
+		syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseSprints, RunItemSprint, "",
+			fmt.Sprintf("FAILED to create sprint '%s'", sprint.Name))
 		created <- false
 	}
 }
 
-func (syncOps *SyncOperations) updateSprint(sprint jiraCommunicator.SprintWithMeta, updating chan bool) {
+func (syncOps *SyncOperations) updateSprint(externalProjectId int32, sprint jiraCommunicator.SprintWithMeta,
+	updating chan bool) {
+
 	toSync := jiraCommunicator.SprintWithMeta{}
 	toSync.Id = sprint.Id
 	toSync.Name = sprint.Name
@@ -111,17 +244,137 @@ func (syncOps *SyncOperations) updateSprint(sprint jiraCommunicator.SprintWithMe
 	toSync.StartDate = sprint.StartDate
 	toSync.EndDate = sprint.EndDate
 	toSync.RapidView = sprint.RapidView
-	updateErr := syncOps.jira.UpdateSprintInJira(&toSync)
+	updateErr := syncOps.updateSprintInJira(&toSync)
 	if updateErr == nil {
 		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
 			fmt.Sprintf("Update sprint successful for task with ID: %d", toSync.Id))
+		syncOps.reportItem(externalProjectId, RunEventUpdated, RunPhaseSprints, RunItemSprint, fmt.Sprint(toSync.Id),
+			toSync.Name)
 	} else {
 		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
 			fmt.Sprintf("FAILED to update sprint for task with ID: %d", toSync.Id))
+		syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseSprints, RunItemSprint, fmt.Sprint(toSync.Id),
+			fmt.Sprintf("FAILED to update sprint: %v", updateErr))
+	}
+	updating <- true
+}
+
+func (syncOps *SyncOperations) createEpic(externalProjectId int32, project *jiraCommunicator.Project,
+	epic jiraCommunicator.IssueWithMeta, created chan bool) {
+
+	createEpic := syncOps.epic.GenerateEpicForCreation(project, &epic)
+	justCreated := syncOps.jira.CreateIssueInJira(createEpic)
+	if justCreated == nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to create epic '%s'", epic.Fields.Summary))
+		syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseEpics, RunItemEpic, "",
+			fmt.Sprintf("FAILED to create epic '%s'", epic.Fields.Summary))
+		created <- false
+		return
+	}
+	saved := syncOps.datasource.SaveEpicAndTaskSyncHistory(externalProjectId, &epic, justCreated.Id)
+	if saved != true {
+		// Without its sync history the epic would be created again on every run, so it's removed for the next run
+		// to create it afresh
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to save sync history of epic %s", justCreated.Key))
+		syncOps.removeUnrecordedEpic(justCreated.Key)
+		syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseEpics, RunItemEpic, justCreated.Key,
+			fmt.Sprintf("FAILED to save sync history of epic '%s'", epic.Fields.Summary))
+		created <- false
+		return
+	}
+	utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+		fmt.Sprintf("Created epic %s and saved sync history", justCreated.Key))
+	transitionErr := syncOps.transitionIssueIfRequired(externalProjectId, project, justCreated.Id,
+		justCreated.Key, epic.Fields.Status.Name)
+	if transitionErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+			fmt.Sprintf("Status of epic %s is out of sync: %v", justCreated.Key, transitionErr))
+	}
+	syncOps.reportItem(externalProjectId, RunEventCreated, RunPhaseEpics, RunItemEpic, justCreated.Key,
+		epic.Fields.Summary)
+	created <- true
+}
+
+func (syncOps *SyncOperations) removeUnrecordedEpic(epicKey string) {
+	if syncOps.jira.DeleteIssueInJira(epicKey) != true {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to remove unrecorded epic %s, it will be created again", epicKey))
+	}
+}
+
+func (syncOps *SyncOperations) updateEpic(externalProjectId int32, project *jiraCommunicator.Project,
+	epic jiraCommunicator.IssueWithMeta, updating chan bool) {
+
+	updateEpic := syncOps.epic.GenerateEpicForUpdate(project, &epic)
+	if updateEpic == nil || syncOps.jira.UpdateIssueInJira(updateEpic) != true {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to update epic %s", epic.ExistingIssueKey))
+		syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseEpics, RunItemEpic, epic.ExistingIssueKey,
+			fmt.Sprintf("FAILED to update epic %s", epic.ExistingIssueKey))
+		updating <- false
+		return
+	}
+	utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+		fmt.Sprintf("Update epic successful for task with ID: %d", epic.MavenlinkTaskId))
+	transitionErr := syncOps.transitionIssueIfRequired(externalProjectId, project, epic.Id,
+		epic.ExistingIssueKey, epic.Fields.Status.Name)
+	if transitionErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+			fmt.Sprintf("Status of epic %s is out of sync: %v", epic.ExistingIssueKey, transitionErr))
 	}
+	syncOps.reportItem(externalProjectId, RunEventUpdated, RunPhaseEpics, RunItemEpic, epic.ExistingIssueKey,
+		epic.Fields.Summary)
 	updating <- true
 }
 
+// Get the JIRA epic a Mavenlink sub-task was synced to
+func (syncOps *SyncOperations) getJiraEpicForMavenlinkTask(project *jiraCommunicator.Project,
+	parentTaskId int32) (string, *jiraCommunicator.Issue) {
+
+	epicId := syncOps.datasource.GetJiraEpicIdFromMavenlinkTaskId(parentTaskId)
+	if len(epicId) == 0 {
+		return "", nil
+	}
+	return epicId, syncOps.jira.RetrieveIssueInProject(project.Key, epicId)
+}
+
+func (syncOps *SyncOperations) updateEpicOfIssueIfRequired(externalProjectId int32, project *jiraCommunicator.Project,
+	issue jiraCommunicator.IssueWithMeta) string {
+
+	epicId, epic := syncOps.getJiraEpicForMavenlinkTask(project, issue.MavenlinkParentTaskId)
+	recordedParentId := syncOps.datasource.GetMavenlinkParentTaskIdFromMavenlinkTaskId(issue.MavenlinkTaskId)
+	if issue.MavenlinkParentTaskId == recordedParentId {
+		return epicId
+	}
+	if epic == nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to find the epic of Mavenlink task %d for issue %s", issue.MavenlinkParentTaskId,
+				issue.ExistingIssueKey))
+		return epicId
+	}
+	epicUpdated := syncOps.jira.UpdateEpicInfoForJiraIssue(epic.Key, issue.ExistingIssueKey)
+	if epicUpdated != true {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to update epic info for issue %s", issue.ExistingIssueKey))
+		return epicId
+	}
+	updateErr := syncOps.datasource.UpdateIssueAndTaskSyncHistory(externalProjectId, project, &issue, "", epicId)
+	if updateErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+			fmt.Sprintf("Moved issue '%s' to epic %s", issue.ExistingIssueKey, epic.Key))
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			"FAILED to save sync history")
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("Error: %v", updateErr))
+	} else {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+			fmt.Sprintf("Moved issue '%s' to epic %s and saved sync history", issue.ExistingIssueKey, epic.Key))
+	}
+	return epicId
+}
+
 func (syncOps *SyncOperations) updateSprintOfIssueIfRequired(externalProjectId int32, project *jiraCommunicator.Project,
 	issue jiraCommunicator.IssueWithMeta) string {
 
@@ -135,7 +388,7 @@ func (syncOps *SyncOperations) updateSprintOfIssueIfRequired(externalProjectId i
 				fmt.Sprintf("FAILED to update sprint info for issue %s", issue.ExistingIssueKey))
 		} else {
 			updateErr := syncOps.datasource.UpdateIssueAndTaskSyncHistory(externalProjectId, project, &issue,
-				sprintId)
+				sprintId, "")
 			if updateErr != nil {
 				utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
 					fmt.Sprintf("Updated sprint info %s for issue '%s'",
@@ -164,7 +417,7 @@ func (syncOps *SyncOperations) recordWorklogUpdate(issueChannel <-chan jiraCommu
 	if justUpdated != nil {
 		saved := syncOps.datasource.UpdateWorklogAndTimeEntrySyncHistory(issue.Id, justUpdated.Id,
 			worklog.MavenlinkTimeentryId, worklog.Author.EmailAddress, worklog.MavenlinkTimeentryUserId,
-			worklog.TimeSpentSeconds)
+			worklog.MavenlinkTimeentryUserEmail, worklog.FallbackAuthor, worklog.TimeSpentSeconds)
 		if saved == true {
 			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
 				fmt.Sprintf("Update worklog %s and saved sync history", justUpdated.Id))
@@ -188,7 +441,7 @@ func (syncOps *SyncOperations) recordWorklogCreation(issueChannel <-chan jiraCom
 		if justCreated != nil {
 			saved := syncOps.datasource.SaveWorklogAndTimeEntrySyncHistory(issue.Id, justCreated.Id,
 				worklog.MavenlinkTimeentryId, worklog.Author.EmailAddress, worklog.MavenlinkTimeentryUserId,
-				worklog.TimeSpentSeconds)
+				worklog.MavenlinkTimeentryUserEmail, worklog.FallbackAuthor, worklog.TimeSpentSeconds)
 			if saved == true {
 				utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
 					fmt.Sprintf("Created worklog %s and saved sync history", justCreated.Id))
@@ -207,46 +460,302 @@ func (syncOps *SyncOperations) recordWorklogCreation(issueChannel <-chan jiraCom
 	return false
 }
 
-func (syncOps *SyncOperations) createWorklogs(project *jiraCommunicator.Project,
+func (syncOps *SyncOperations) createWorklogs(externalProjectId int32, project *jiraCommunicator.Project,
 	worklog jiraCommunicator.WorklogWithMeta, created chan bool) {
 
 	issue := syncOps.datasource.GetJiraIssueFromTaskInSubTask(project.Key, worklog.MavenlinkTaskInSubTaskId)
 	if issue != nil {
 		recorded := syncOps.recordWorklogCreation(issue, &worklog)
 		if recorded {
+			syncOps.reportItem(externalProjectId, RunEventCreated, RunPhaseWorklogs, RunItemWorklog, "",
+				fmt.Sprintf("From Mavenlink time entry %v", worklog.MavenlinkTimeentryId))
 			created <- true
 		} else {
 			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
 				fmt.Sprintf("FAILED to create worklog - %s", worklog.Id))
+			syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseWorklogs, RunItemWorklog, "",
+				fmt.Sprintf("FAILED to create worklog from Mavenlink time entry %v", worklog.MavenlinkTimeentryId))
 			created <- false
 		}
+		return
 	}
 	created <- false
 }
 
-func (syncOps *SyncOperations) updateWorklogs(project *jiraCommunicator.Project,
+func (syncOps *SyncOperations) updateWorklogs(externalProjectId int32, project *jiraCommunicator.Project,
 	worklog jiraCommunicator.WorklogWithMeta, update chan bool) {
 
 	issue := syncOps.datasource.GetJiraIssueFromTaskInSubTask(project.Key, worklog.MavenlinkTaskInSubTaskId)
 	recorded := syncOps.recordWorklogUpdate(issue, &worklog)
 	if recorded {
+		syncOps.reportItem(externalProjectId, RunEventUpdated, RunPhaseWorklogs, RunItemWorklog, worklog.Id,
+			fmt.Sprintf("From Mavenlink time entry %v", worklog.MavenlinkTimeentryId))
 		update <- true
 	} else {
 		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross, "Update failed !!")
+		syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseWorklogs, RunItemWorklog, worklog.Id,
+			fmt.Sprintf("FAILED to update worklog from Mavenlink time entry %v", worklog.MavenlinkTimeentryId))
 		update <- false
 	}
-	update <- false
+}
+
+func (syncOps *SyncOperations) moveWorklog(project *jiraCommunicator.Project, moved POGO.MovedWorklog,
+	move chan bool) {
+
+	// The previous worklog is only removed once its replacement is created, the sync history only pointing at the
+	// replacement once the previous one is gone. Any failure removes the replacement, leaving the time logged once
+	issue := <-syncOps.datasource.GetJiraIssueFromTaskInSubTask(project.Key, moved.Worklog.MavenlinkTaskInSubTaskId)
+	if len(issue.Id) == 0 {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			"FAILED to retrieve JIRA issue's key from task in sub-task")
+		move <- false
+		return
+	}
+	justCreated := syncOps.jira.CreateWorklogInJira(issue.Key, &moved.Worklog)
+	if justCreated == nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to recreate worklog %s in issue %s", moved.Worklog.Id, issue.Key))
+		move <- false
+		return
+	}
+	removed := syncOps.jira.DeleteWorklogInJira(moved.PreviousIssueId, moved.Worklog.Id)
+	if removed != true {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to remove worklog %s from issue %s, after recreating it in issue %s as %s",
+				moved.Worklog.Id, moved.PreviousIssueId, issue.Key, justCreated.Id))
+		// The previous worklog is still the synced one, the move being retried by the next run
+		syncOps.removeRecreatedWorklog(issue.Key, justCreated.Id)
+		move <- false
+		return
+	}
+	saved := syncOps.datasource.UpdateWorklogAndTimeEntrySyncHistory(issue.Id, justCreated.Id,
+		moved.Worklog.MavenlinkTimeentryId, moved.Worklog.Author.EmailAddress,
+		moved.Worklog.MavenlinkTimeentryUserId, moved.Worklog.MavenlinkTimeentryUserEmail,
+		moved.Worklog.FallbackAuthor, moved.Worklog.TimeSpentSeconds)
+	if saved != true {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to save sync history of worklog %s recreated in issue %s", justCreated.Id,
+				issue.Key))
+		// Without its sync history the recreated worklog would be logged again, while the time entry, left without
+		// a worklog, is logged anew by the next run
+		syncOps.removeRecreatedWorklog(issue.Key, justCreated.Id)
+		move <- false
+		return
+	}
+	action := "Moved"
+	if moved.Reassigned {
+		action = fmt.Sprintf("Reassigned to %s", moved.Worklog.Author.Name)
+	}
+	utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+		fmt.Sprintf("%s worklog %s in issue %s as %s and saved sync history", action, moved.Worklog.Id,
+			issue.Key, justCreated.Id))
+	move <- true
+}
+
+// Remove a worklog recreated by a move that couldn't be completed
+func (syncOps *SyncOperations) removeRecreatedWorklog(issueKey string, worklogId string) {
+	if syncOps.jira.DeleteWorklogInJira(issueKey, worklogId) != true {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to remove recreated worklog %s from issue %s, its time is logged twice", worklogId,
+				issueKey))
+	}
+}
+
+func (syncOps *SyncOperations) deleteWorklog(syncedWorklog *datasourceCommunicator.ExternalTimeEntries,
+	deleted chan bool) {
+
+	removed := syncOps.jira.DeleteWorklogInJira(fmt.Sprint(syncedWorklog.Source1TaskId),
+		fmt.Sprint(syncedWorklog.Source1LogId))
+	if removed == true {
+		saved := syncOps.datasource.DeleteWorklogAndTimeEntrySyncHistory(syncedWorklog)
+		if saved == true {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				fmt.Sprintf("Deleted worklog %d and saved sync history", syncedWorklog.Source1LogId))
+		} else {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				fmt.Sprintf("Deleted worklog %d", syncedWorklog.Source1LogId))
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				fmt.Sprintf("FAILED to save sync history"))
+		}
+		deleted <- true
+	} else {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to delete worklog - %d", syncedWorklog.Source1LogId))
+		deleted <- false
+	}
+}
+
+func (syncOps *SyncOperations) createTimeentry(workspaceId int32, timeentry POGO.TimeentryWithMeta,
+	created chan bool) {
+
+	justCreated := syncOps.mavenlink.CreateTimeentryInMavenlink(workspaceId, &timeentry.Timeentry)
+	if justCreated != nil {
+		saved := syncOps.datasource.SaveWorklogAndTimeEntrySyncHistory(timeentry.JiraIssueId,
+			timeentry.JiraWorklogId, justCreated.Id, timeentry.JiraUserEmail, timeentry.Timeentry.User.Id,
+			timeentry.Timeentry.User.EmailAddress, false, int64(timeentry.Timeentry.TimeInMinutes*60))
+		if saved == true {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				fmt.Sprintf("Created time entry %s from worklog %s and saved sync history", justCreated.Id,
+					timeentry.JiraWorklogId))
+		} else {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				fmt.Sprintf("Created time entry %s from worklog %s", justCreated.Id, timeentry.JiraWorklogId))
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				fmt.Sprintf("FAILED to save sync history"))
+		}
+		created <- true
+	} else {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to create time entry from worklog - %s", timeentry.JiraWorklogId))
+		created <- false
+	}
+}
+
+func (syncOps *SyncOperations) createComment(project *jiraCommunicator.Project, comment POGO.CommentWithMeta,
+	created chan bool) {
+
+	issue := <-syncOps.datasource.GetJiraIssueFromTaskInSubTask(project.Key, comment.MavenlinkTaskId)
+	if len(issue.Id) == 0 {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			"FAILED to retrieve JIRA issue's key from task in sub-task")
+		created <- false
+		return
+	}
+	justCreated := syncOps.jira.CreateCommentInJira(issue.Key, comment.Body)
+	if justCreated != nil {
+		saved := syncOps.datasource.SaveCommentAndPostSyncHistory(issue.Id, justCreated.Id,
+			comment.MavenlinkTaskId, comment.MavenlinkPostId, comment.MavenlinkPostUpdated)
+		if saved == true {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				fmt.Sprintf("Created comment %s in issue %s and saved sync history", justCreated.Id, issue.Key))
+		} else {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				fmt.Sprintf("Created comment %s in issue %s", justCreated.Id, issue.Key))
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				fmt.Sprintf("FAILED to save sync history"))
+		}
+		created <- true
+	} else {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to create comment from post - %s", comment.MavenlinkPostId))
+		created <- false
+	}
+}
+
+func (syncOps *SyncOperations) updateComment(project *jiraCommunicator.Project, comment POGO.CommentWithMeta,
+	updated chan bool) {
+
+	issue := <-syncOps.datasource.GetJiraIssueFromTaskInSubTask(project.Key, comment.MavenlinkTaskId)
+	if len(issue.Id) == 0 {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			"FAILED to retrieve JIRA issue's key from task in sub-task")
+		updated <- false
+		return
+	}
+	justUpdated := syncOps.jira.UpdateCommentInJira(issue.Key, comment.Id, comment.Body)
+	if justUpdated != nil {
+		saved := syncOps.datasource.UpdateCommentAndPostSyncHistory(issue.Id, justUpdated.Id,
+			comment.MavenlinkPostId, comment.MavenlinkPostUpdated)
+		if saved == true {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				fmt.Sprintf("Updated comment %s in issue %s and saved sync history", justUpdated.Id, issue.Key))
+		} else {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				fmt.Sprintf("Updated comment %s in issue %s", justUpdated.Id, issue.Key))
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				fmt.Sprintf("FAILED to save sync history"))
+		}
+		updated <- true
+	} else {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to update comment %s from post - %s", comment.Id, comment.MavenlinkPostId))
+		updated <- false
+	}
+}
+
+// Download a Mavenlink attachment to a temporary file, hashing its content on the way
+func (syncOps *SyncOperations) spoolAttachment(attachment *mavenlinkCommunicator.Attachment) (*os.File, string,
+	error) {
+
+	content, downloadErr := syncOps.mavenlink.DownloadAttachmentFromMavenlink(attachment.Id,
+		utility.MaxAttachmentSize)
+	if downloadErr != nil {
+		return nil, "", downloadErr
+	}
+	defer content.Close()
+	spooled, spoolErr := ioutil.TempFile("", "mavenlink-attachment-")
+	if spoolErr != nil {
+		return nil, "", spoolErr
+	}
+	contentHash, hashErr := syncOps.common.GetContentHash(io.TeeReader(content, spooled))
+	if hashErr == nil {
+		_, hashErr = spooled.Seek(0, io.SeekStart)
+	}
+	if hashErr != nil {
+		spooled.Close()
+		os.Remove(spooled.Name())
+		return nil, "", hashErr
+	}
+	return spooled, contentHash, nil
+}
+
+func (syncOps *SyncOperations) createAttachment(project *jiraCommunicator.Project,
+	attachment *mavenlinkCommunicator.Attachment) bool {
+
+	issue := <-syncOps.datasource.GetJiraIssueFromTaskInSubTask(project.Key, attachment.StoryId)
+	if len(issue.Id) == 0 {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			"FAILED to retrieve JIRA issue's key from task in sub-task")
+		return false
+	}
+	spooled, contentHash, spoolErr := syncOps.spoolAttachment(attachment)
+	if spoolErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to download attachment '%s'", attachment.FileName))
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("Error: %v", spoolErr))
+		return false
+	}
+	defer os.Remove(spooled.Name())
+	defer spooled.Close()
+	var jiraAttachmentId string
+	if duplicate := syncOps.datasource.GetAttachmentFromContentHash(issue.Id, contentHash); duplicate != nil {
+		jiraAttachmentId = fmt.Sprint(duplicate.Source1AttachmentId)
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+			fmt.Sprintf("Attachment '%s' already exists in issue %s", attachment.FileName, issue.Key))
+	} else {
+		justUploaded := syncOps.jira.UploadAttachmentToJira(issue.Key, attachment.FileName, spooled)
+		if justUploaded == nil {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				fmt.Sprintf("FAILED to upload attachment '%s' to issue %s", attachment.FileName, issue.Key))
+			return false
+		}
+		jiraAttachmentId = justUploaded.Id
+	}
+	saved := syncOps.datasource.SaveAttachmentSyncHistory(issue.Id, jiraAttachmentId, attachment.StoryId,
+		attachment.Id, contentHash)
+	if saved == true {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+			fmt.Sprintf("Synced attachment '%s' to issue %s and saved sync history", attachment.FileName,
+				issue.Key))
+	} else {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+			fmt.Sprintf("Synced attachment '%s' to issue %s", attachment.FileName, issue.Key))
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to save sync history"))
+	}
+	return true
 }
 
 func (syncOps *SyncOperations) updateIssueAndRecordSyncHistory(externalProjectId int32, project *jiraCommunicator.Project,
-	issue jiraCommunicator.IssueWithMeta, sprintId string) {
+	issue jiraCommunicator.IssueWithMeta, sprintId string, epicId string) bool {
 
-	updateIssue := syncOps.issue.GenerateIssueForUpdate(project, issue)
+	updateIssue := syncOps.issue.GenerateIssueForUpdate(externalProjectId, project, issue)
 	if updateIssue != nil {
 		justUpdated := syncOps.jira.UpdateIssueInJira(updateIssue)
 		if justUpdated == true {
 			updateErr := syncOps.datasource.UpdateIssueAndTaskSyncHistory(externalProjectId, project, &issue,
-				sprintId)
+				sprintId, epicId)
 			if updateErr != nil {
 				utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
 					fmt.Sprintf("Updated issue %s in sprint %s via JIRA API", issue.ExistingIssueKey, sprintId))
@@ -257,22 +766,141 @@ func (syncOps *SyncOperations) updateIssueAndRecordSyncHistory(externalProjectId
 					fmt.Sprintf("Updated issue %s in sprint %s and saved sync history",
 						issue.ExistingIssueKey, sprintId))
 			}
-		} else {
-			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
-				fmt.Sprintf("FAILED to update issue %s via JIRA API", issue.ExistingIssueKey))
+			return true
 		}
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to update issue %s via JIRA API", issue.ExistingIssueKey))
 	} else {
 		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
 			fmt.Sprintf("FAILED to generate an update object for the issue %s", issue.ExistingIssueKey))
 	}
+	return false
+}
+
+// Move the JIRA issue through its workflow to the status equivalent to the Mavenlink task's state
+func (syncOps *SyncOperations) transitionIssueIfRequired(externalProjectId int32, project *jiraCommunicator.Project,
+	issueId string, issueKey string, mavenlinkState string) error {
+
+	if syncOps.isJiraMaster() {
+		return nil
+	}
+	targetStatus := syncOps.common.GetJiraStatusFromMetadata(mavenlinkState, "", externalProjectId)
+	if targetStatus == nil {
+		return errors.New(fmt.Sprintf("No JIRA status is equivalent to '%s'", mavenlinkState))
+	}
+	existingIssue := syncOps.jira.RetrieveIssueInProject(project.Key, issueId)
+	if existingIssue == nil || existingIssue.Fields == nil || existingIssue.Fields.Status == nil {
+		return errors.New(fmt.Sprintf("Failed to retrieve the status of issue %s", issueKey))
+	}
+	if syncOps.common.IsEquivalentToJira(existingIssue.Fields.Status.Name, mavenlinkState,
+		&synchronizer.EquivalenceTypes{Status: true, ExternalProjectId: externalProjectId}) {
+		return nil
+	}
+	// JIRA only lists the transitions available from an issue's current status, so only a path made of transitions
+	// seen offered from each status along the way is followed, the issue being left as it is when none is known
+	workflowKey := project.Key
+	if existingIssue.Fields.Issuetype != nil {
+		workflowKey = project.Key + "/" + existingIssue.Fields.Issuetype.Id
+	}
+	fromStatus := existingIssue.Fields.Status
+	currentStatus := fromStatus
+	for steps := 0; ; steps++ {
+		if syncOps.common.IsEquivalentToJira(currentStatus.Name, mavenlinkState,
+			&synchronizer.EquivalenceTypes{Status: true, ExternalProjectId: externalProjectId}) {
+
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				fmt.Sprintf("Transitioned issue %s from '%s' to '%s' in %d step(s)", issueKey, fromStatus.Name,
+					currentStatus.Name, steps))
+			return nil
+		}
+		if steps == utility.MaxTransitionSteps {
+			return errors.New(fmt.Sprintf("Gave up transitioning issue %s to '%s' after %d steps, it's in '%s'",
+				issueKey, targetStatus.Name, steps, currentStatus.Name))
+		}
+		syncOps.transition.LearnTransitions(workflowKey, currentStatus,
+			syncOps.jira.GetTransitionsForJiraIssue(issueKey))
+		path, pathErr := syncOps.transition.GetKnownTransitionPath(workflowKey, currentStatus, targetStatus)
+		if pathErr != nil {
+			return errors.New(fmt.Sprintf("Issue %s can't be transitioned, it's in '%s': %v", issueKey,
+				currentStatus.Name, pathErr))
+		}
+		if !syncOps.jira.TransitionJiraIssue(issueKey, path[0].Id) {
+			return errors.New(fmt.Sprintf("Failed to apply transition '%s' to issue %s", path[0].Name, issueKey))
+		}
+		transitionedIssue := syncOps.jira.RetrieveIssueInProject(project.Key, issueId)
+		if transitionedIssue == nil || transitionedIssue.Fields == nil || transitionedIssue.Fields.Status == nil {
+			return errors.New(fmt.Sprintf("Failed to retrieve the status of issue %s", issueKey))
+		}
+		currentStatus = transitionedIssue.Fields.Status
+	}
+}
+
+// Move the JIRA issue to the issue type equivalent to the Mavenlink task's story type, when its workflow allows it
+func (syncOps *SyncOperations) changeIssueTypeIfRequired(externalProjectId int32, project *jiraCommunicator.Project,
+	issueId string, issueKey string, mavenlinkStoryType string) error {
+
+	existingIssue := syncOps.jira.RetrieveIssueInProject(project.Key, issueId)
+	if existingIssue == nil || existingIssue.Fields == nil || existingIssue.Fields.Issuetype == nil {
+		return errors.New(fmt.Sprintf("Failed to retrieve the issue type of issue %s", issueKey))
+	}
+	if syncOps.common.IsEquivalentToJira(existingIssue.Fields.Issuetype.Name, mavenlinkStoryType,
+		&synchronizer.EquivalenceTypes{IssueType: true, ExternalProjectId: externalProjectId}) {
+		return nil
+	}
+	targetIssueType := syncOps.common.GetJiraIssueTypeFromMetadata(mavenlinkStoryType, "", externalProjectId)
+	if targetIssueType == nil {
+		return errors.New(fmt.Sprintf("No JIRA issue type is equivalent to '%s'", mavenlinkStoryType))
+	}
+	var allowed bool
+	for _, issueType := range syncOps.jira.GetAllowedIssueTypesForJiraIssue(issueKey) {
+		if issueType.Id == targetIssueType.Id {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errors.New(fmt.Sprintf("Conflict, issue %s can't be moved from '%s' to '%s'", issueKey,
+			existingIssue.Fields.Issuetype.Name, targetIssueType.Name))
+	}
+	if !syncOps.jira.ChangeIssueTypeInJira(issueKey, targetIssueType.Id) {
+		return errors.New(fmt.Sprintf("Failed to move issue %s to '%s'", issueKey, targetIssueType.Name))
+	}
+	utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+		fmt.Sprintf("Moved issue %s from '%s' to '%s'", issueKey, existingIssue.Fields.Issuetype.Name,
+			targetIssueType.Name))
+	return nil
 }
 
 func (syncOps *SyncOperations) updateIssue(externalProjectId int32, project *jiraCommunicator.Project,
-	epic *jiraCommunicator.Issue, issue jiraCommunicator.IssueWithMeta, updates chan bool) {
+	epic *jiraCommunicator.Issue, epicMode bool, issue jiraCommunicator.IssueWithMeta, updates chan bool) {
 
-	sprintId := syncOps.updateSprintOfIssueIfRequired(externalProjectId, project, issue)
+	var sprintId string
+	var epicId string
+	if epicMode {
+		epicId = syncOps.updateEpicOfIssueIfRequired(externalProjectId, project, issue)
+	} else {
+		sprintId = syncOps.updateSprintOfIssueIfRequired(externalProjectId, project, issue)
+	}
 	if issue.ToBeUpdated == true {
-		syncOps.updateIssueAndRecordSyncHistory(externalProjectId, project, issue, sprintId)
+		if syncOps.updateIssueAndRecordSyncHistory(externalProjectId, project, issue, sprintId, epicId) {
+			syncOps.reportItem(externalProjectId, RunEventUpdated, RunPhaseIssues, RunItemIssue,
+				issue.ExistingIssueKey, issue.Fields.Summary)
+		} else {
+			syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseIssues, RunItemIssue,
+				issue.ExistingIssueKey, fmt.Sprintf("FAILED to update issue %s", issue.ExistingIssueKey))
+		}
+		issueTypeErr := syncOps.changeIssueTypeIfRequired(externalProjectId, project, issue.Id,
+			issue.ExistingIssueKey, issue.Fields.Issuetype.Name)
+		if issueTypeErr != nil {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+				fmt.Sprintf("Issue type of issue %s is out of sync: %v", issue.ExistingIssueKey, issueTypeErr))
+		}
+		transitionErr := syncOps.transitionIssueIfRequired(externalProjectId, project, issue.Id,
+			issue.ExistingIssueKey, issue.Fields.Status.Name)
+		if transitionErr != nil {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+				fmt.Sprintf("Status of issue %s is out of sync: %v", issue.ExistingIssueKey, transitionErr))
+		}
 		updates <- true
 	} else {
 		updates <- false
@@ -280,19 +908,44 @@ func (syncOps *SyncOperations) updateIssue(externalProjectId int32, project *jir
 }
 
 func (syncOps *SyncOperations) createIssue(externalProjectId int32, project *jiraCommunicator.Project,
-	epic *jiraCommunicator.Issue, issue jiraCommunicator.IssueWithMeta, created chan bool) {
+	epic *jiraCommunicator.Issue, epicMode bool, issue jiraCommunicator.IssueWithMeta, created chan bool) {
 
-	sprintId := syncOps.datasource.GetJiraSprintIdFromMavenlinkTaskId(issue.MavenlinkParentTaskId)
-	if len(sprintId) > 0 {
-		createIssue := syncOps.issue.GenerateIssueForCreation(project, &issue, sprintId)
+	var sprintId string
+	var epicId string
+	if epicMode {
+		// Each Mavenlink sub-task is an epic of its own, instead of a sprint in the project's epic
+		epicId, epic = syncOps.getJiraEpicForMavenlinkTask(project, issue.MavenlinkParentTaskId)
+		if epic == nil {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross, "FAILED to retrieve epic for issue")
+			syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseIssues, RunItemIssue, "",
+				fmt.Sprintf("FAILED to retrieve epic for Mavenlink task %d", issue.MavenlinkTaskId))
+			created <- false
+			return
+		}
+	} else {
+		sprintId = syncOps.datasource.GetJiraSprintIdFromMavenlinkTaskId(issue.MavenlinkParentTaskId)
+	}
+	if len(sprintId) > 0 || len(epicId) > 0 {
+		createIssue := syncOps.issue.GenerateIssueForCreation(externalProjectId, project, &issue, sprintId)
 		if nil != createIssue {
 			justCreated := syncOps.jira.CreateIssueInJira(createIssue)
 			if justCreated != nil {
-				saved := syncOps.datasource.SaveIssueAndTaskSyncHistory(externalProjectId, sprintId,
+				saved := syncOps.datasource.SaveIssueAndTaskSyncHistory(externalProjectId, sprintId, epicId,
 					issue.MavenlinkParentTaskId, issue.MavenlinkTaskId, justCreated)
 				if saved == true {
-					utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
-						fmt.Sprintf("Created issue in sprint %s and saved sync history", sprintId))
+					if epicMode {
+						utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+							fmt.Sprintf("Created issue in epic %s and saved sync history", epic.Key))
+					} else {
+						utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+							fmt.Sprintf("Created issue in sprint %s and saved sync history", sprintId))
+					}
+					transitionErr := syncOps.transitionIssueIfRequired(externalProjectId, project, justCreated.Id,
+						justCreated.Key, issue.Fields.Status.Name)
+					if transitionErr != nil {
+						utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+							fmt.Sprintf("Status of issue %s is out of sync: %v", justCreated.Key, transitionErr))
+					}
 					epicTagged := syncOps.jira.UpdateEpicInfoForJiraIssue(epic.Key, justCreated.Key)
 					if epicTagged {
 						utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
@@ -307,33 +960,111 @@ func (syncOps *SyncOperations) createIssue(externalProjectId int32, project *jir
 					utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
 						"FAILED to save sync history")
 				}
+				syncOps.reportItem(externalProjectId, RunEventCreated, RunPhaseIssues, RunItemIssue,
+					justCreated.Key, issue.Fields.Summary)
 				created <- true
 			} else {
 				utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross, "FAILED to create issue")
+				syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseIssues, RunItemIssue, "",
+					fmt.Sprintf("FAILED to create issue for Mavenlink task %d", issue.MavenlinkTaskId))
 				created <- false
 			}
 		} else {
 			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
 				"FAILED to generate issue for creation")
+			syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseIssues, RunItemIssue, "",
+				fmt.Sprintf("FAILED to generate issue for Mavenlink task %d", issue.MavenlinkTaskId))
 			created <- false
 		}
 	} else {
 		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross, "FAILED to retrieve sprint id for issue")
+		syncOps.reportItem(externalProjectId, RunEventFailed, RunPhaseIssues, RunItemIssue, "",
+			fmt.Sprintf("FAILED to retrieve sprint id for Mavenlink task %d", issue.MavenlinkTaskId))
 		created <- false
 	}
 }
 
-func (syncOps *SyncOperations) syncTasksAndSprints(externalProjectId int32,
-	sprintsAndTasks *POGO.SprintAndTask) <-chan bool {
-	channel := make(chan bool)
-	go func() {
-		if len(sprintsAndTasks.GetRapidViews()) > 0 {
-			toBeCreated, toBeCreatedClosed := syncOps.sprint.PrepareSprintsForCreation(sprintsAndTasks)
-			toBeSynced, toBeSyncedClosed := syncOps.sprint.PrepareSprintsForUpdate(sprintsAndTasks)
+func (syncOps *SyncOperations) updateTask(task POGO.TaskWithMeta, updates chan bool) {
+	toSync := mavenlinkCommunicator.Task{}
+	toSync.Id = task.Task.Id
+	toSync.State = task.Task.State
+	toSync.User = task.Task.User
+	if task.StateChanged {
+		toSync.State = task.State
+	}
+	if task.AssigneeChanged {
+		toSync.User = task.Assignee
+	}
+	justUpdated := syncOps.mavenlink.UpdateTaskInMavenlink(&toSync)
+	if justUpdated == true {
+		// Keep the retrieved task in line with JIRA so that the issue sync doesn't revert the change
+		task.Task.State = toSync.State
+		task.Task.User = toSync.User
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+			fmt.Sprintf("Updated task %s from issue %s via Mavenlink API", task.Task.Id, task.ExistingIssueKey))
+		updates <- true
+	} else {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to update task %s from issue %s via Mavenlink API", task.Task.Id,
+				task.ExistingIssueKey))
+		updates <- false
+	}
+}
 
-			synced := make(chan bool)
-			syncedCount := 0
-			var quitWaiting bool
+func (syncOps *SyncOperations) deleteIssue(externalProject *datasourceCommunicator.ExternalProject,
+	project *jiraCommunicator.Project, syncedTask *datasourceCommunicator.ExternalTasks, deleted chan bool) {
+
+	issue := syncOps.jira.RetrieveIssueInProject(project.Key, fmt.Sprint(syncedTask.Source1TaskId))
+	if issue == nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to retrieve issue %d of deleted task %d", syncedTask.Source1TaskId,
+				syncedTask.Source2TaskId))
+		deleted <- false
+		return
+	}
+	var applied bool
+	switch strings.ToLower(externalProject.DeletionPolicy) {
+	case utility.DeletionPolicyClose:
+		applied = syncOps.jira.CloseIssueInJira(issue.Key)
+	case utility.DeletionPolicyLabel:
+		applied = syncOps.jira.AddLabelToJiraIssue(issue.Key, utility.DeletedTaskLabel)
+	default:
+		applied = true
+	}
+	if applied != true {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("FAILED to apply '%s' policy to issue %s of deleted task %d",
+				externalProject.DeletionPolicy, issue.Key, syncedTask.Source2TaskId))
+		deleted <- false
+		return
+	}
+	deleteErr := syncOps.datasource.DeleteIssueAndTaskSyncHistory(syncedTask)
+	if deleteErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+			fmt.Sprintf("Handled issue %s of deleted task %d", issue.Key, syncedTask.Source2TaskId))
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			"FAILED to save sync history")
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("Error: %v", deleteErr))
+	} else {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+			fmt.Sprintf("Handled issue %s of deleted task %d and saved sync history", issue.Key,
+				syncedTask.Source2TaskId))
+	}
+	deleted <- true
+}
+
+func (syncOps *SyncOperations) syncTasksAndSprints(externalProjectId int32,
+	sprintsAndTasks *POGO.SprintAndTask) <-chan bool {
+	channel := make(chan bool)
+	go func() {
+		if sprintsAndTasks.GetRapidView() != nil {
+			toBeCreated, toBeCreatedClosed := syncOps.sprint.PrepareSprintsForCreation(sprintsAndTasks)
+			toBeSynced, toBeSyncedClosed := syncOps.sprint.PrepareSprintsForUpdate(sprintsAndTasks)
+
+			synced := make(chan bool)
+			syncedCount := 0
+			var quitWaiting bool
 			var creationCompleted bool
 			var updateCompleted bool
 			for {
@@ -350,7 +1081,7 @@ func (syncOps *SyncOperations) syncTasksAndSprints(externalProjectId int32,
 						quitWaiting = true
 					}
 				case toBe := <-toBeSynced:
-					go syncOps.updateSprint(toBe, synced)
+					go syncOps.updateSprint(externalProjectId, toBe, synced)
 					syncedCount++
 				case <-toBeSyncedClosed:
 					updateCompleted = true
@@ -379,6 +1110,58 @@ func (syncOps *SyncOperations) syncTasksAndSprints(externalProjectId int32,
 	return channel
 }
 
+func (syncOps *SyncOperations) syncTasksAndEpics(externalProjectId int32, project *jiraCommunicator.Project,
+	sprintsAndTasks *POGO.SprintAndTask) <-chan bool {
+
+	channel := make(chan bool)
+	go func() {
+		toBeCreated, toBeCreatedClosed := syncOps.epic.PrepareEpicsForCreation(sprintsAndTasks)
+		toBeSynced, toBeSyncedClosed := syncOps.epic.PrepareEpicsForUpdate(sprintsAndTasks)
+
+		synced := make(chan bool)
+		syncedCount := 0
+		var quitWaiting bool
+		var creationCompleted bool
+		var updateCompleted bool
+		for {
+			if creationCompleted && updateCompleted && quitWaiting {
+				break
+			}
+			select {
+			case toBe := <-toBeCreated:
+				go syncOps.createEpic(externalProjectId, project, toBe, synced)
+				syncedCount++
+			case <-toBeCreatedClosed:
+				creationCompleted = true
+				if updateCompleted {
+					quitWaiting = true
+				}
+			case toBe := <-toBeSynced:
+				go syncOps.updateEpic(externalProjectId, project, toBe, synced)
+				syncedCount++
+			case <-toBeSyncedClosed:
+				updateCompleted = true
+				if creationCompleted {
+					quitWaiting = true
+				}
+			}
+		}
+		if syncedCount > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint,
+				fmt.Sprintf("Triggered %d epic sync jobs", syncedCount))
+		}
+		for syncedIndex := 0; syncedIndex < syncedCount; syncedIndex++ {
+			<-synced
+		}
+		if syncedCount <= 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				"No JIRA epics require synchronization!")
+		}
+		channel <- true
+	}()
+	return channel
+}
+
 func (syncOps *SyncOperations) syncTasksAndIssues(externalProjectId int32,
 	issuesAndTasks *POGO.IssueAndTask) <-chan bool {
 
@@ -399,7 +1182,8 @@ func (syncOps *SyncOperations) syncTasksAndIssues(externalProjectId int32,
 			}
 			select {
 			case toBe := <-toBeCreated:
-				go syncOps.createIssue(externalProjectId, issuesAndTasks.GetProject(), issuesAndTasks.GetEpic(), toBe, synced)
+				go syncOps.createIssue(externalProjectId, issuesAndTasks.GetProject(), issuesAndTasks.GetEpic(),
+					issuesAndTasks.IsEpicMode(), toBe, synced)
 				syncedCount++
 			case <-toBeCreatedClosed:
 				creationCompleted = true
@@ -407,7 +1191,8 @@ func (syncOps *SyncOperations) syncTasksAndIssues(externalProjectId int32,
 					quitWaiting = true
 				}
 			case toBe := <-toBeSynced:
-				go syncOps.updateIssue(externalProjectId, issuesAndTasks.GetProject(), issuesAndTasks.GetEpic(), toBe, synced)
+				go syncOps.updateIssue(externalProjectId, issuesAndTasks.GetProject(), issuesAndTasks.GetEpic(),
+					issuesAndTasks.IsEpicMode(), toBe, synced)
 				syncedCount++
 			case <-toBeSyncedClosed:
 				updateCompleted = true
@@ -435,6 +1220,182 @@ func (syncOps *SyncOperations) syncTasksAndIssues(externalProjectId int32,
 	return channel
 }
 
+func (syncOps *SyncOperations) syncIssuesAndTasks(issuesAndTasks *POGO.IssueAndTask) <-chan bool {
+
+	channel := make(chan bool)
+	go func() {
+		toBeSynced, toBeSyncedClosed := syncOps.task.PrepareTasksForUpdate(issuesAndTasks)
+
+		synced := make(chan bool)
+		syncedCount := 0
+		var updateCompleted bool
+		for !updateCompleted {
+			select {
+			case toBe := <-toBeSynced:
+				go syncOps.updateTask(toBe, synced)
+				syncedCount++
+			case <-toBeSyncedClosed:
+				updateCompleted = true
+			}
+		}
+		if syncedCount > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint,
+				fmt.Sprintf("Triggered %d task sync jobs", syncedCount))
+		}
+		for syncedIndex := 0; syncedIndex < syncedCount; syncedIndex++ {
+			<-synced
+		}
+		if syncedCount <= 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				"No Mavenlink tasks require synchronization!")
+		}
+		channel <- true
+	}()
+	return channel
+}
+
+func (syncOps *SyncOperations) syncDeletedTasksAndIssues(externalProject *datasourceCommunicator.ExternalProject,
+	issuesAndTasks *POGO.IssueAndTask) <-chan bool {
+
+	channel := make(chan bool)
+	go func() {
+		// Tasks of a sub-task that couldn't be retrieved would all be taken for deleted ones
+		if len(issuesAndTasks.GetTaskFailures()) > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				fmt.Sprintf("Failed to retrieve tasks of x%d Mavenlink sub-tasks. Rejecting sync of deleted tasks!",
+					len(issuesAndTasks.GetTaskFailures())))
+			channel <- true
+			return
+		}
+		// As would the tasks of a milestone whose sub-tasks couldn't be retrieved
+		if len(issuesAndTasks.GetSubTaskFailures()) > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				fmt.Sprintf("Failed to retrieve sub-tasks of x%d Mavenlink milestones. Rejecting sync of deleted "+
+					"tasks!", len(issuesAndTasks.GetSubTaskFailures())))
+			channel <- true
+			return
+		}
+		// An empty task list is more likely a failed retrieval than a workspace emptied of tasks
+		if len(issuesAndTasks.GetTasks()) <= 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				"No Mavenlink tasks found. Rejecting sync of deleted tasks!")
+			channel <- true
+			return
+		}
+		syncedTasks := syncOps.datasource.GetIssueAndTaskSyncHistory(externalProject.Id)
+		toBeDeleted := syncOps.issue.GetDeletedTasks(syncedTasks, issuesAndTasks.GetTasks())
+
+		synced := make(chan bool)
+		for _, toBe := range toBeDeleted {
+			go syncOps.deleteIssue(externalProject, issuesAndTasks.GetProject(), toBe, synced)
+		}
+		if len(toBeDeleted) > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint,
+				fmt.Sprintf("Triggered %d deleted task sync jobs", len(toBeDeleted)))
+		}
+		for syncedIndex := 0; syncedIndex < len(toBeDeleted); syncedIndex++ {
+			<-synced
+		}
+		if len(toBeDeleted) <= 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				"No deleted Mavenlink tasks require synchronization!")
+		}
+		channel <- true
+	}()
+	return channel
+}
+
+func (syncOps *SyncOperations) syncPostsAndComments(issuesAndTasks *POGO.IssueAndTask) <-chan bool {
+
+	channel := make(chan bool)
+	go func() {
+		toBeCreated, toBeCreatedClosed := syncOps.comment.PrepareCommentsForCreation(issuesAndTasks)
+		toBeSynced, toBeSyncedClosed := syncOps.comment.PrepareCommentsForUpdate(issuesAndTasks)
+
+		synced := make(chan bool)
+		syncedCount := 0
+		var quitWaiting bool
+		var creationCompleted bool
+		var updateCompleted bool
+		for {
+			if creationCompleted && updateCompleted && quitWaiting {
+				break
+			}
+			select {
+			case toBe := <-toBeCreated:
+				go syncOps.createComment(issuesAndTasks.GetProject(), toBe, synced)
+				syncedCount++
+			case <-toBeCreatedClosed:
+				creationCompleted = true
+				if updateCompleted {
+					quitWaiting = true
+				}
+			case toBe := <-toBeSynced:
+				go syncOps.updateComment(issuesAndTasks.GetProject(), toBe, synced)
+				syncedCount++
+			case <-toBeSyncedClosed:
+				updateCompleted = true
+				if creationCompleted {
+					quitWaiting = true
+				}
+			}
+		}
+		if syncedCount > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint,
+				fmt.Sprintf("Triggered %d comment sync jobs", syncedCount))
+		}
+		for syncedIndex := 0; syncedIndex < syncedCount; syncedIndex++ {
+			<-synced
+		}
+		if syncedCount == 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				"No JIRA comments require synchronization!")
+		}
+		channel <- true
+	}()
+	return channel
+}
+
+func (syncOps *SyncOperations) syncAttachments(issuesAndTasks *POGO.IssueAndTask) <-chan bool {
+
+	channel := make(chan bool)
+	go func() {
+		toBeCreated := syncOps.attachment.GetAttachmentsToBeProcessed(issuesAndTasks)
+
+		// A task's attachments go to its issue one at a time, so identical files are caught by the content hash of
+		// the one synced first, while only a few attachments are spooled at once
+		tasksAttachments := map[string][]*mavenlinkCommunicator.Attachment{}
+		for _, toBe := range toBeCreated {
+			tasksAttachments[toBe.StoryId] = append(tasksAttachments[toBe.StoryId], toBe)
+		}
+		slots := make(chan bool, utility.MaxConcurrentAttachments)
+		synced := make(chan bool)
+		for _, taskAttachments := range tasksAttachments {
+			go func(taskAttachments []*mavenlinkCommunicator.Attachment) {
+				for _, toBe := range taskAttachments {
+					slots <- true
+					created := syncOps.createAttachment(issuesAndTasks.GetProject(), toBe)
+					<-slots
+					synced <- created
+				}
+			}(taskAttachments)
+		}
+		if len(toBeCreated) > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint,
+				fmt.Sprintf("Triggered %d attachment sync jobs", len(toBeCreated)))
+		}
+		for syncedIndex := 0; syncedIndex < len(toBeCreated); syncedIndex++ {
+			<-synced
+		}
+		if len(toBeCreated) == 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				"No JIRA attachments require synchronization!")
+		}
+		channel <- true
+	}()
+	return channel
+}
+
 func (syncOps *SyncOperations) syncWorklogsAndTimeEntries(projectId int32,
 	issuesAndTasks *POGO.IssueAndTask) <-chan bool {
 
@@ -442,6 +1403,9 @@ func (syncOps *SyncOperations) syncWorklogsAndTimeEntries(projectId int32,
 	go func() {
 		project := syncOps.jira.GetJiraProject(projectId)
 		if project == nil {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				fmt.Sprintf("Failed to find JIRA project '%d'. Rejecting sync of worklogs!", projectId))
+			channel <- true
 			return
 		}
 
@@ -459,7 +1423,7 @@ func (syncOps *SyncOperations) syncWorklogsAndTimeEntries(projectId int32,
 			}
 			select {
 			case toBe := <-toBeCreated:
-				go syncOps.createWorklogs(project, toBe, synced)
+				go syncOps.createWorklogs(issuesAndTasks.GetExternalProjectId(), project, toBe, synced)
 				syncedCount++
 			case <-toBeCreatedClosed:
 				creationCompleted = true
@@ -467,7 +1431,7 @@ func (syncOps *SyncOperations) syncWorklogsAndTimeEntries(projectId int32,
 					quitWaiting = true
 				}
 			case toBe := <-toBeSynced:
-				go syncOps.updateWorklogs(project, toBe, synced)
+				go syncOps.updateWorklogs(issuesAndTasks.GetExternalProjectId(), project, toBe, synced)
 				syncedCount++
 			case <-toBeSyncedClosed:
 				updateCompleted = true
@@ -492,15 +1456,487 @@ func (syncOps *SyncOperations) syncWorklogsAndTimeEntries(projectId int32,
 	return channel
 }
 
-// Check if the sync configuration is valid
+func (syncOps *SyncOperations) syncRemovedAndMovedTimeEntries(project *jiraCommunicator.Project,
+	issuesAndTasks *POGO.IssueAndTask) <-chan bool {
+
+	channel := make(chan bool)
+	go func() {
+		// A task whose time entries couldn't be retrieved would have all its worklogs taken for removed ones
+		if len(issuesAndTasks.GetTimeentryFailures()) > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				fmt.Sprintf("Failed to retrieve time entries of x%d Mavenlink tasks. Rejecting sync of removed "+
+					"& moved worklogs!", len(issuesAndTasks.GetTimeentryFailures())))
+			channel <- true
+			return
+		}
+		// Neither would the tasks of a sub-task, or the sub-tasks of a milestone, that couldn't be retrieved
+		if len(issuesAndTasks.GetTaskFailures()) > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				fmt.Sprintf("Failed to retrieve tasks of x%d Mavenlink sub-tasks. Rejecting sync of removed "+
+					"& moved worklogs!", len(issuesAndTasks.GetTaskFailures())))
+			channel <- true
+			return
+		}
+		if len(issuesAndTasks.GetSubTaskFailures()) > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+				fmt.Sprintf("Failed to retrieve sub-tasks of x%d Mavenlink milestones. Rejecting sync of removed "+
+					"& moved worklogs!", len(issuesAndTasks.GetSubTaskFailures())))
+			channel <- true
+			return
+		}
+		// Only the worklogs of issues whose tasks had their time entries retrieved can be told removed
+		loadedTaskIds := map[int32]bool{}
+		for _, task := range issuesAndTasks.GetTasks() {
+			loadedTaskIds[syncOps.common.GetIdFromString(task.Id)] = true
+		}
+		var syncedWorklogs []*datasourceCommunicator.ExternalTimeEntries
+		for _, issue := range issuesAndTasks.GetIssues() {
+			syncedTask := syncOps.datasource.GetSyncedTaskFromJiraIssueId(syncOps.common.GetIdFromString(issue.Id))
+			if syncedTask == nil || !loadedTaskIds[syncedTask.Source2TaskId] {
+				continue
+			}
+			syncedWorklogs = append(syncedWorklogs, syncOps.datasource.GetWorklogAndTimeEntrySyncHistory(issue.Id)...)
+		}
+		toBeDeleted := syncOps.worklog.GetRemovedTimeEntries(syncedWorklogs, issuesAndTasks.GetTimeentries())
+		toBeMoved, toBeMovedClosed := syncOps.worklog.PrepareWorklogsForMove(issuesAndTasks)
+
+		synced := make(chan bool)
+		syncedCount := 0
+		for _, toBe := range toBeDeleted {
+			go syncOps.deleteWorklog(toBe, synced)
+			syncedCount++
+		}
+		var moveCompleted bool
+		for !moveCompleted {
+			select {
+			case toBe := <-toBeMoved:
+				go syncOps.moveWorklog(issuesAndTasks.GetProject(), toBe, synced)
+				syncedCount++
+			case <-toBeMovedClosed:
+				moveCompleted = true
+			}
+		}
+		if syncedCount > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint,
+				fmt.Sprintf("Triggered %d removed/moved worklog sync jobs", syncedCount))
+		}
+		for syncedIndex := 0; syncedIndex < syncedCount; syncedIndex++ {
+			<-synced
+		}
+		if syncedCount == 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				"No removed or moved JIRA worklogs require synchronization!")
+		}
+		channel <- true
+	}()
+	return channel
+}
+
+func (syncOps *SyncOperations) syncTimeEntriesFromWorklogs(workspaceId int32,
+	issuesAndTasks *POGO.IssueAndTask) <-chan bool {
+
+	channel := make(chan bool)
+	go func() {
+		toBeCreated, toBeCreatedClosed := syncOps.worklog.PrepareTimeEntriesForCreation(issuesAndTasks)
+
+		synced := make(chan bool)
+		syncedCount := 0
+		var creationCompleted bool
+		for !creationCompleted {
+			select {
+			case toBe := <-toBeCreated:
+				go syncOps.createTimeentry(workspaceId, toBe, synced)
+				syncedCount++
+			case <-toBeCreatedClosed:
+				creationCompleted = true
+			}
+		}
+		if syncedCount > 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint,
+				fmt.Sprintf("Triggered %d time entry sync jobs", syncedCount))
+		}
+		for syncedIndex := 0; syncedIndex < syncedCount; syncedIndex++ {
+			<-synced
+		}
+		if syncedCount == 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+				"No Mavenlink time entries require synchronization!")
+		}
+		channel <- true
+	}()
+	return channel
+}
+
+// Check if the project's run must sync everything, it having no marks yet or its last full run being too old
+func (syncOps *SyncOperations) isFullRunDue(cursors map[string]*datasourceCommunicator.ExternalSyncCursors,
+	startedAt time.Time) bool {
+
+	interval := utility.DefaultFullSyncInterval
+	if syncOps.environment != nil && len(syncOps.environment.FullSyncInterval) > 0 {
+		configuredInterval, configuredIntervalErr := time.ParseDuration(syncOps.environment.FullSyncInterval)
+		if configuredIntervalErr != nil {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+				fmt.Sprintf("Invalid full sync interval '%s', using %v", syncOps.environment.FullSyncInterval,
+					interval))
+		} else {
+			interval = configuredInterval
+		}
+	}
+	for _, entityType := range []string{utility.SyncCursorTasks, utility.SyncCursorTimeEntries,
+		utility.SyncCursorFullRun} {
+
+		if cursors[entityType] == nil {
+			return true
+		}
+	}
+	lastFullRun, lastFullRunErr := time.Parse(time.RFC3339, cursors[utility.SyncCursorFullRun].UpdatedSince)
+	return lastFullRunErr != nil || startedAt.Sub(lastFullRun) >= interval
+}
+
+// Collect the Mavenlink tasks changed since the project's marks, directly, through their time entries or, with JIRA as
+// master, through their JIRA issues, along with the JIRA issues they're synced to
+func (syncOps *SyncOperations) retrieveChangedIssuesAndTasks(externalProject *datasourceCommunicator.ExternalProject,
+	jiraProject *jiraCommunicator.Project, subTasks []*mavenlinkCommunicator.Task,
+	cursors map[string]*datasourceCommunicator.ExternalSyncCursors, issuesAndTasks *POGO.IssueAndTask) error {
+
+	changedTasks, changedTasksErr := syncOps.mavenlink.RetrieveTasksUpdatedSinceInWorkspace(
+		externalProject.Source2ProjectId, cursors[utility.SyncCursorTasks].UpdatedSince)
+	if changedTasksErr != nil {
+		return changedTasksErr
+	}
+	changedTimeentries, changedTimeentriesErr := syncOps.mavenlink.RetrieveTimeEntriesUpdatedSinceInWorkspace(
+		externalProject.Source2ProjectId, cursors[utility.SyncCursorTimeEntries].UpdatedSince)
+	if changedTimeentriesErr != nil {
+		return changedTimeentriesErr
+	}
+	// With JIRA as master, statuses & assignees are changed in JIRA, where worklogs are logged too, so the tasks of
+	// the issues updated there are synced as well
+	var changedIssues []jiraCommunicator.Issue
+	if syncOps.isJiraMaster() {
+		updatedSince, updatedSinceErr := time.Parse(time.RFC3339, cursors[utility.SyncCursorTasks].UpdatedSince)
+		if updatedSinceErr != nil {
+			return updatedSinceErr
+		}
+		var changedIssuesErr error
+		changedIssues, changedIssuesErr = syncOps.jira.RetrieveIssuesUpdatedSinceInProject(jiraProject.Key,
+			updatedSince)
+		if changedIssuesErr != nil {
+			return changedIssuesErr
+		}
+	}
+
+	// Only tasks in the sub-tasks of the configured milestones are synced
+	subTaskIds := map[string]bool{}
+	for _, subTask := range subTasks {
+		subTaskIds[subTask.Id] = true
+	}
+	// Tasks by ID, the ones that didn't change themselves being retrieved as they're needed
+	knownTasks := map[string]*mavenlinkCommunicator.Task{}
+	for index := range changedTasks {
+		knownTasks[changedTasks[index].Id] = &changedTasks[index]
+	}
+	getTask := func(taskId string) *mavenlinkCommunicator.Task {
+		if task, known := knownTasks[taskId]; known {
+			return task
+		}
+		task := syncOps.mavenlink.GetTaskInMavenlink(externalProject.Source2ProjectId, taskId)
+		knownTasks[taskId] = task
+		return task
+	}
+	collected := map[string]bool{}
+	excluded := map[string]bool{}
+	// Tasks that time entries were moved between, which are synced together or not at all
+	linked := map[string][]string{}
+	var tasks []mavenlinkCommunicator.Task
+	collect := func(task *mavenlinkCommunicator.Task) {
+		if subTaskIds[task.ParentId] && !collected[task.Id] {
+			tasks = append(tasks, *task)
+			collected[task.Id] = true
+		}
+	}
+	for index := range changedTasks {
+		collect(&changedTasks[index])
+	}
+	for _, timeEntry := range changedTimeentries {
+		if len(timeEntry.StoryId) == 0 {
+			continue
+		}
+		// A time entry moved from another task is only detected along with the issue its worklog is in, without
+		// which it'd be logged again, while that issue without the time entry would have its worklog removed
+		previousTaskId := syncOps.getSyncedTaskIdOfTimeEntry(timeEntry.Id)
+		task := getTask(timeEntry.StoryId)
+		if task == nil {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+				fmt.Sprintf("Failed to find Mavenlink task %s of time entry %s, leaving it to the next full run",
+					timeEntry.StoryId, timeEntry.Id))
+			if len(previousTaskId) > 0 {
+				excluded[previousTaskId] = true
+			}
+			continue
+		}
+		if len(previousTaskId) > 0 && previousTaskId != task.Id {
+			previousTask := getTask(previousTaskId)
+			if previousTask == nil || !subTaskIds[previousTask.ParentId] {
+				utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+					fmt.Sprintf("Failed to find Mavenlink task %s that time entry %s was synced from, leaving "+
+						"task '%s' to the next full run", previousTaskId, timeEntry.Id, task.Title))
+				excluded[task.Id] = true
+				continue
+			}
+			collect(previousTask)
+			linked[previousTask.Id] = append(linked[previousTask.Id], task.Id)
+			linked[task.Id] = append(linked[task.Id], previousTask.Id)
+		}
+		collect(task)
+	}
+	for _, changedIssue := range changedIssues {
+		syncedTask := syncOps.datasource.GetSyncedTaskFromJiraIssueId(syncOps.common.GetIdFromString(changedIssue.Id))
+		if syncedTask == nil {
+			continue
+		}
+		task := getTask(fmt.Sprint(syncedTask.Source2TaskId))
+		if task == nil {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+				fmt.Sprintf("Failed to find Mavenlink task %d of JIRA issue %s, leaving it to the next full run",
+					syncedTask.Source2TaskId, changedIssue.Key))
+			continue
+		}
+		collect(task)
+	}
+
+	taskIssues := map[string]*jiraCommunicator.Issue{}
+	for _, task := range tasks {
+		if excluded[task.Id] {
+			continue
+		}
+		syncedTask := syncOps.datasource.GetSyncedTaskFromMavenlinkTaskId(syncOps.common.GetIdFromString(task.Id))
+		if syncedTask == nil {
+			continue
+		}
+		issue := syncOps.jira.RetrieveIssueInProject(jiraProject.Key, fmt.Sprint(syncedTask.Source1TaskId))
+		if issue == nil {
+			// Without its issue, the synced task would be taken for a new one & duplicated
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+				fmt.Sprintf("Failed to retrieve JIRA issue '%d' of Mavenlink task '%s', leaving it to the "+
+					"next full run", syncedTask.Source1TaskId, task.Title))
+			excluded[task.Id] = true
+			continue
+		}
+		taskIssues[task.Id] = issue
+	}
+	var pending []string
+	for taskId := range excluded {
+		pending = append(pending, taskId)
+	}
+	for len(pending) > 0 {
+		taskId := pending[0]
+		pending = pending[1:]
+		for _, linkedTaskId := range linked[taskId] {
+			if !excluded[linkedTaskId] {
+				excluded[linkedTaskId] = true
+				pending = append(pending, linkedTaskId)
+			}
+		}
+	}
+	var issues []jiraCommunicator.Issue
+	var syncableTasks []mavenlinkCommunicator.Task
+	for _, task := range tasks {
+		if excluded[task.Id] {
+			continue
+		}
+		if issue, synced := taskIssues[task.Id]; synced {
+			issues = append(issues, *issue)
+		}
+		syncableTasks = append(syncableTasks, task)
+	}
+	issuesAndTasks.SetIssues(issues)
+	issuesAndTasks.SetTasks(syncableTasks)
+	return nil
+}
+
+// Get the Mavenlink task whose JIRA issue a time entry's worklog is in, when the time entry is synced
+func (syncOps *SyncOperations) getSyncedTaskIdOfTimeEntry(timeEntryId string) string {
+	syncedTimeEntry := syncOps.datasource.GetSyncedTimeEntryFromMavenlinkTimeEntryId(
+		syncOps.common.GetIdFromString(timeEntryId))
+	if syncedTimeEntry == nil {
+		return ""
+	}
+	syncedTask := syncOps.datasource.GetSyncedTaskFromJiraIssueId(syncedTimeEntry.Source1TaskId)
+	if syncedTask == nil {
+		return ""
+	}
+	return fmt.Sprint(syncedTask.Source2TaskId)
+}
+
+// Move the project's marks up to the start of its successful run
+func (syncOps *SyncOperations) saveSyncCursors(externalProjectId int32, startedAt time.Time, fullRun bool) {
+	updatedSince := startedAt.Add(-utility.SyncCursorOverlap).Format(time.RFC3339)
+	saved := syncOps.datasource.SaveSyncCursor(externalProjectId, utility.SyncCursorTasks, updatedSince) &&
+		syncOps.datasource.SaveSyncCursor(externalProjectId, utility.SyncCursorTimeEntries, updatedSince)
+	if saved && fullRun {
+		saved = syncOps.datasource.SaveSyncCursor(externalProjectId, utility.SyncCursorFullRun,
+			startedAt.Format(time.RFC3339))
+	}
+	if !saved {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+			"Failed to save the sync cursors, changes may be synced again")
+	}
+}
+
+// Get the identity mapping of the project's users, along with its fallback worklog author. Without it assignees &
+// worklog authors would be matched differently, so a failure is returned for the run to be skipped
+func (syncOps *SyncOperations) getIdentityMapping(
+	externalProject *datasourceCommunicator.ExternalProject) (*POGO.IdentityMapping, error) {
+
+	identities, identitiesErr := syncOps.datasource.GetIdentityMapping(externalProject.Id)
+	if identitiesErr != nil {
+		return nil, identitiesErr
+	}
+	if len(externalProject.FallbackWorklogAuthor) > 0 {
+		identities.SetFallbackAuthor(jiraCommunicator.Author{Name: externalProject.FallbackWorklogAuthor})
+	}
+	if syncOps.environment != nil {
+		identities.SetSyncAccount(syncOps.environment.JiraSyncAccount)
+	}
+	return identities, nil
+}
+
+// Check if the board named in the sync configuration exists in the JIRA project
+func (syncOps *SyncOperations) doesBoardExistInJiraProject(syncConfiguration *datasourceCommunicator.ExternalProject,
+	exists chan bool) {
+
+	rapidViews := make(chan []jiraCommunicator.GreenhopperRapidView)
+	sprintsAndTasks := &POGO.SprintAndTask{}
+	go syncOps.jira.RetrieveRapidViewsInProject(syncConfiguration.ProjectKey, rapidViews)
+	sprintsAndTasks.SetRapidViews(<-rapidViews)
+	_, rapidViewErr := syncOps.sprint.GetConfiguredRapidView(sprintsAndTasks.GetRapidViews(),
+		syncConfiguration.Board)
+	if rapidViewErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Cross,
+			fmt.Sprintf("Invalid JIRA board for '%s': %v", syncConfiguration.ProjectName, rapidViewErr))
+		exists <- false
+		return
+	}
+	exists <- true
+}
+
+// Create the project's epic from the Mavenlink workspace's name & set it as the configuration's epic
+func (syncOps *SyncOperations) createProjectEpic(
+	syncConfiguration *datasourceCommunicator.ExternalProject) (*jiraCommunicator.Issue, error) {
+
+	workspace := syncOps.mavenlink.GetWorkspaceInMavenlink(syncConfiguration.Source2ProjectId)
+	if workspace == nil {
+		return nil, errors.New(fmt.Sprintf("Mavenlink workspace %d not found", syncConfiguration.Source2ProjectId))
+	}
+	epic := jiraCommunicator.IssueWithMeta{}
+	epic.Fields = new(jiraCommunicator.Fields)
+	epic.Fields.Summary = workspace.Title
+	epic.Fields.Description = workspace.Description
+	justCreated := syncOps.jira.CreateIssueInJira(syncOps.epic.GenerateEpicForCreation(
+		&jiraCommunicator.Project{Key: syncConfiguration.ProjectKey}, &epic))
+	if justCreated == nil {
+		return nil, errors.New(fmt.Sprintf("Failed to create epic '%s'", workspace.Title))
+	}
+	// The label records the epic as the configuration's, for it to be reused if its ID fails to be saved
+	if syncOps.jira.AddLabelToJiraIssue(justCreated.Key, getProvisionedEpicLabel(syncConfiguration)) != true {
+		syncOps.removeUnrecordedEpic(justCreated.Key)
+		return nil, errors.New(fmt.Sprintf("Failed to label created epic %s", justCreated.Key))
+	}
+	epicNumber := syncOps.common.GetIssueNumberFromKey(syncConfiguration.ProjectKey, justCreated.Key)
+	if epicNumber == 0 {
+		return nil, errors.New(fmt.Sprintf("Failed to read the epic number of created epic %s", justCreated.Key))
+	}
+	syncConfiguration.EpicId = epicNumber
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Check,
+		fmt.Sprintf("Created epic %s '%s' for '%s'", justCreated.Key, workspace.Title,
+			syncConfiguration.ProjectName))
+	return justCreated, nil
+}
+
+// Get the label recording an epic as provisioned for the sync configuration
+func getProvisionedEpicLabel(syncConfiguration *datasourceCommunicator.ExternalProject) string {
+	return utility.ProvisionedEpicLabelPrefix + fmt.Sprint(syncConfiguration.Id)
+}
+
+// Get the epic a previous run provisioned for the configuration, so it's reused when its ID failed to be saved.
+// Epics are only known by their label, as one merely named after the workspace may well be another team's
+func (syncOps *SyncOperations) getProvisionedEpic(
+	syncConfiguration *datasourceCommunicator.ExternalProject) (*jiraCommunicator.Issue, error) {
+
+	issues, issuesErr := syncOps.jira.RetrieveIssuesWithLabelInProject(syncConfiguration.ProjectKey,
+		getProvisionedEpicLabel(syncConfiguration))
+	if issuesErr != nil {
+		return nil, issuesErr
+	}
+	for _, issue := range issues {
+		if issue.Fields != nil && issue.Fields.Issuetype != nil &&
+			strings.EqualFold(issue.Fields.Issuetype.Name, utility.JiraEpicIssueTypeName) {
+			return &issue, nil
+		}
+	}
+	return nil, nil
+}
+
+// Create the project's epic from the Mavenlink workspace's name when it's missing & the configuration allows it,
+// before the configuration is validated for a sync
+func (syncOps *SyncOperations) ProvisionEpicIfRequired(syncConfiguration *datasourceCommunicator.ExternalProject) error {
+	if syncConfiguration.EpicMode || !syncConfiguration.ProvisionEpic {
+		return nil
+	}
+	if syncConfiguration.EpicId != 0 {
+		epicKey := syncConfiguration.ProjectKey + "-" + fmt.Sprint(syncConfiguration.EpicId)
+		existingEpic, existingEpicErr := syncOps.jira.FindEpicInJiraProject(epicKey)
+		if existingEpicErr != nil {
+			// Only an epic known to be missing is replaced
+			return existingEpicErr
+		}
+		if existingEpic != nil {
+			return nil
+		}
+	}
+	provisionedEpic, provisionedEpicErr := syncOps.getProvisionedEpic(syncConfiguration)
+	if provisionedEpicErr != nil {
+		// Without knowing if there's one, another epic could be created
+		return provisionedEpicErr
+	}
+	if provisionedEpic != nil {
+		epicNumber := syncOps.common.GetIssueNumberFromKey(syncConfiguration.ProjectKey, provisionedEpic.Key)
+		if epicNumber == 0 {
+			return errors.New(fmt.Sprintf("Failed to read the epic number of epic %s", provisionedEpic.Key))
+		}
+		syncConfiguration.EpicId = epicNumber
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Check,
+			fmt.Sprintf("Reusing epic %s provisioned for '%s'", provisionedEpic.Key, syncConfiguration.ProjectName))
+	} else {
+		justCreated, createErr := syncOps.createProjectEpic(syncConfiguration)
+		if createErr != nil {
+			return createErr
+		}
+		provisionedEpic = justCreated
+	}
+	saveErr := syncOps.datasource.SaveEpicOfSyncConfiguration(syncConfiguration)
+	if saveErr != nil {
+		return errors.New(fmt.Sprintf("Failed to save epic %s to the configuration: %v", provisionedEpic.Key,
+			saveErr))
+	}
+	return nil
+}
+
+// Check if the sync configuration is valid, without changing anything
 func (syncOps *SyncOperations) IsAValidSyncConfiguration(syncConfiguration *datasourceCommunicator.ExternalProject) bool {
 	validConfiguration := true
 	validity := make(chan bool)
 	go syncOps.mavenlink.DoesWorkspaceExistInMavenlink(syncConfiguration.Source2ProjectId, validity)
 	go syncOps.jira.DoesProjectExistInJira(syncConfiguration.Source1ProjectId, validity)
-	go syncOps.jira.DoesEpicExistInJiraProject(
-		syncConfiguration.ProjectKey+"-"+fmt.Sprint(syncConfiguration.EpicId), validity)
-	for i := 0; i < 3; i++ {
+	validations := 2
+	// Projects in epic mode have neither a project epic nor a board to sync sprints to
+	if !syncConfiguration.EpicMode {
+		go syncOps.jira.DoesEpicExistInJiraProject(
+			syncConfiguration.ProjectKey+"-"+fmt.Sprint(syncConfiguration.EpicId), validity)
+		go syncOps.doesBoardExistInJiraProject(syncConfiguration, validity)
+		validations += 2
+	}
+	for i := 0; i < validations; i++ {
 		validConfiguration = syncOps.common.IsContinuouslyTrue(validConfiguration, <-validity)
 	}
 	return validConfiguration
@@ -510,14 +1946,17 @@ func (syncOps *SyncOperations) SyncMavenlinkToJira(externalProject *datasourceCo
 	success chan bool) {
 
 	tasks := make(chan []mavenlinkCommunicator.Task)
-	subTasks := make(chan []mavenlinkCommunicator.Task)
-	tasksInSubTasks := make(chan []mavenlinkCommunicator.Task)
+	subTasks := make(chan POGO.SubTasksInMilestones)
+	tasksInSubTasks := make(chan POGO.TasksInSubTasks)
 	issuesInSprints := make(chan []jiraCommunicator.Issue)
-	tasksInSubTasksTimeentries := make(chan []mavenlinkCommunicator.Timeentry)
+	tasksInSubTasksTimeentries := make(chan POGO.TaskTimeentries)
+	tasksInSubTasksPosts := make(chan []mavenlinkCommunicator.Post)
+	tasksInSubTasksAttachments := make(chan []mavenlinkCommunicator.Attachment)
 	issuesInSprintsWorklogs := make(chan []jiraCommunicator.Worklog)
 	rapidViews := make(chan []jiraCommunicator.GreenhopperRapidView)
 	sprints := make(chan []jiraCommunicator.Sprint)
 	users := make(chan []jiraCommunicator.Author)
+	mavenlinkUsers := make(chan []mavenlinkCommunicator.User)
 	sprintsAndTasks := &POGO.SprintAndTask{}
 	issuesAndTasks := &POGO.IssueAndTask{}
 
@@ -526,67 +1965,165 @@ func (syncOps *SyncOperations) SyncMavenlinkToJira(externalProject *datasourceCo
 		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.CircularBulletPoint+utility.CircularBulletPoint,
 			fmt.Sprintf("Failed to find JIRA project '%d'!!", externalProject.Source1ProjectId))
 		success <- false
+		return
+	}
+	// In epic mode each Mavenlink sub-task is an epic of its own, so there's no project epic
+	var jiraEpic *jiraCommunicator.Issue
+	if !externalProject.EpicMode {
+		jiraEpic = syncOps.jira.GetEpicInJiraProject(
+			externalProject.ProjectKey + "-" + fmt.Sprint(externalProject.EpicId))
+		if jiraEpic == nil {
+			utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.CircularBulletPoint+utility.CircularBulletPoint,
+				fmt.Sprintf("Failed to find JIRA epic '%s'!!",
+					externalProject.ProjectKey+"-"+fmt.Sprint(externalProject.EpicId)))
+			success <- false
+			return
+		}
 	}
-	jiraEpic := syncOps.jira.GetEpicInJiraProject(
-		externalProject.ProjectKey + "-" + fmt.Sprint(externalProject.EpicId))
-	if jiraEpic == nil {
+
+	identities, identitiesErr := syncOps.getIdentityMapping(externalProject)
+	if identitiesErr != nil {
 		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.CircularBulletPoint+utility.CircularBulletPoint,
-			fmt.Sprintf("Failed to find JIRA epic '%s'!!",
-				externalProject.ProjectKey+"-"+fmt.Sprint(externalProject.EpicId)))
+			fmt.Sprintf("Failed to retrieve the identity mapping, leaving the project to the next run: %v !!",
+				identitiesErr))
 		success <- false
+		return
 	}
-
 	utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
 		fmt.Sprintf("'%s' JIRA project detected", jiraProject.Name))
-	utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
-		fmt.Sprintf("'%s' JIRA epic detected", jiraEpic.Fields.Summary))
-	utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Therefore,
-		"Bootstrapping project data from Mavenlink & JIRA")
-
-	go syncOps.mavenlink.RetrieveTasksInWorkspaceWithTitle(externalProject.Source2ProjectId, tasks,
-		"Construction")
-	go syncOps.jira.RetrieveRapidViewsInProject(jiraProject.Key, rapidViews)
-	go syncOps.jira.RetrieveSprintsInProject(jiraProject.Key, sprints)
-
-	sprintsAndTasks.SetTasks(<-tasks)
-	sprintsAndTasks.SetRapidViews(<-rapidViews)
-	sprintsAndTasks.SetSprints(<-sprints)
-	utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint, "Retrieved")
-	utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.Check, "Milestone task - 'Construction'")
-	utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.Check,
-		fmt.Sprintf("Rapid views - x%d", len(sprintsAndTasks.GetRapidViews())))
-	utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.Check,
-		fmt.Sprintf("Sprints - x%d", len(sprintsAndTasks.GetSprints())))
-
-	if sprintsAndTasks.GetRapidViews() == nil ||
-		len(sprintsAndTasks.GetRapidViews()) <= 0 ||
-		sprintsAndTasks.GetTasks() == nil ||
-		len(sprintsAndTasks.GetTasks()) <= 0 {
-		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.CircularBulletPoint+utility.CircularBulletPoint,
-			"Failed to find valid JIRA RapidViews or Tasks !!")
-		success <- false
+	if jiraEpic != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+			fmt.Sprintf("'%s' JIRA epic detected", jiraEpic.Fields.Summary))
+	} else {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Check,
+			"Epic mode, syncing Mavenlink sub-tasks as JIRA epics")
 	}
-	taskIdInt64, taskIdInt64Err := strconv.ParseInt(sprintsAndTasks.GetTasks()[0].Id, 10, 32)
-	if taskIdInt64Err != nil {
-		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.CircularBulletPoint+utility.CircularBulletPoint,
-			"Failed to convert task id !!")
-		success <- false
+	syncOps.loadProjectEquivalence(externalProject.Id)
+	sprintsAndTasks.SetExternalProjectId(externalProject.Id)
+	issuesAndTasks.SetExternalProjectId(externalProject.Id)
+	startedAt := time.Now().UTC()
+	cursors, cursorsErr := syncOps.datasource.GetSyncCursors(externalProject.Id)
+	if cursorsErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+			fmt.Sprintf("Failed to retrieve the sync cursors, syncing everything: %v", cursorsErr))
 	}
+	fullRun := cursorsErr != nil || syncOps.isFullRunDue(cursors, startedAt)
+	if fullRun {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Therefore,
+			"Bootstrapping project data from Mavenlink & JIRA")
+	} else {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Therefore,
+			fmt.Sprintf("Bootstrapping project data changed since %s from Mavenlink & JIRA",
+				cursors[utility.SyncCursorTasks].UpdatedSince))
+	}
+	syncOps.reportPhase(externalProject.Id, RunPhaseBootstrapping)
+
+	milestones := syncOps.common.GetMilestonesFromConfiguration(externalProject.Milestones)
+	go syncOps.mavenlink.RetrieveTasksInWorkspaceWithTitleOrId(externalProject.Source2ProjectId, tasks,
+		milestones)
+	if externalProject.EpicMode {
+		epics := make(chan []jiraCommunicator.Issue)
+		go syncOps.jira.RetrieveEpicsInProject(jiraProject.Key, epics)
+		sprintsAndTasks.SetTasks(<-tasks)
+		sprintsAndTasks.SetEpics(<-epics)
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint, "Retrieved")
+		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.Check,
+			fmt.Sprintf("Milestone tasks - x%d of '%s'", len(sprintsAndTasks.GetTasks()),
+				strings.Join(milestones, "', '")))
+		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.Check,
+			fmt.Sprintf("Epics - x%d", len(sprintsAndTasks.GetEpics())))
+
+		if sprintsAndTasks.GetTasks() == nil || len(sprintsAndTasks.GetTasks()) <= 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelTwoLog(
+				utility.CircularBulletPoint+utility.CircularBulletPoint, "Failed to find valid Tasks !!")
+			success <- false
+			return
+		}
+	} else {
+		go syncOps.jira.RetrieveRapidViewsInProject(jiraProject.Key, rapidViews)
+		go syncOps.jira.RetrieveSprintsInProject(jiraProject.Key, sprints)
 
-	go syncOps.mavenlink.RetrieveSubTasksInWorkspace(externalProject.Source2ProjectId, int32(taskIdInt64),
+		sprintsAndTasks.SetTasks(<-tasks)
+		sprintsAndTasks.SetRapidViews(<-rapidViews)
+		sprintsAndTasks.SetSprints(<-sprints)
+		rapidView, rapidViewErr := syncOps.sprint.GetConfiguredRapidView(sprintsAndTasks.GetRapidViews(),
+			externalProject.Board)
+		if rapidViewErr != nil {
+			utility.GetUtilitiesSingleton().Logger.LevelTwoLog(
+				utility.CircularBulletPoint+utility.CircularBulletPoint,
+				fmt.Sprintf("Failed to find JIRA board: %v !!", rapidViewErr))
+			success <- false
+			return
+		}
+		sprintsAndTasks.SetRapidView(rapidView)
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint, "Retrieved")
+		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.Check,
+			fmt.Sprintf("Milestone tasks - x%d of '%s'", len(sprintsAndTasks.GetTasks()),
+				strings.Join(milestones, "', '")))
+		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.Check,
+			fmt.Sprintf("Rapid views - x%d, syncing to '%s'", len(sprintsAndTasks.GetRapidViews()),
+				sprintsAndTasks.GetRapidView().Name))
+		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.Check,
+			fmt.Sprintf("Sprints - x%d", len(sprintsAndTasks.GetSprints())))
+
+		if sprintsAndTasks.GetRapidViews() == nil ||
+			len(sprintsAndTasks.GetRapidViews()) <= 0 ||
+			sprintsAndTasks.GetTasks() == nil ||
+			len(sprintsAndTasks.GetTasks()) <= 0 {
+			utility.GetUtilitiesSingleton().Logger.LevelTwoLog(
+				utility.CircularBulletPoint+utility.CircularBulletPoint,
+				"Failed to find valid JIRA RapidViews or Tasks !!")
+			success <- false
+			return
+		}
+	}
+	go syncOps.retrieveAndCollateMavenlinkSubTasksInMilestones(externalProject, sprintsAndTasks.GetTasks(),
 		subTasks)
-	sprintsAndTasks.SetSubTasks(<-subTasks)
+	retrievedSubTasks := <-subTasks
+	sprintsAndTasks.SetSubTasks(retrievedSubTasks.SubTasks)
+	for _, failedMilestoneId := range retrievedSubTasks.FailedMilestoneIds {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+			fmt.Sprintf("Failed to retrieve sub-tasks of Mavenlink milestone %s", failedMilestoneId))
+		issuesAndTasks.AddSubTaskFailure(failedMilestoneId)
+	}
 
-	go syncOps.retrieveAndCollateMavenlinkTasksInSubTasks(externalProject, sprintsAndTasks.GetSubTasks(),
-		tasksInSubTasks)
-	go syncOps.retrieveAndCollateJiraTasksInSprints(jiraProject, sprintsAndTasks.GetSprints(), issuesInSprints)
+	if fullRun {
+		go syncOps.retrieveAndCollateMavenlinkTasksInSubTasks(externalProject, sprintsAndTasks.GetSubTasks(),
+			tasksInSubTasks)
+		if externalProject.EpicMode {
+			go syncOps.retrieveAndCollateJiraTasksInEpics(jiraProject, sprintsAndTasks.GetEpics(), issuesInSprints)
+		} else {
+			go syncOps.retrieveAndCollateJiraTasksInSprints(jiraProject, sprintsAndTasks.GetSprints(),
+				issuesInSprints)
+		}
+	}
 	go syncOps.jira.GetUsersInProject(jiraProject.Key, users)
+	go syncOps.mavenlink.GetUsersInWorkspace(externalProject.Source2ProjectId, mavenlinkUsers)
 
+	issuesAndTasks.SetIdentityMapping(identities)
+	issuesAndTasks.SetTimezone(externalProject.Timezone)
+	issuesAndTasks.SetEpicMode(externalProject.EpicMode)
 	issuesAndTasks.SetProject(jiraProject)
 	issuesAndTasks.SetEpic(jiraEpic)
 	issuesAndTasks.SetUsers(<-users)
-	issuesAndTasks.SetIssues(<-issuesInSprints)
-	issuesAndTasks.SetTasks(<-tasksInSubTasks)
+	issuesAndTasks.SetMavenlinkUsers(<-mavenlinkUsers)
+	if fullRun {
+		issuesAndTasks.SetIssues(<-issuesInSprints)
+		retrievedTasks := <-tasksInSubTasks
+		issuesAndTasks.SetTasks(retrievedTasks.Tasks)
+		for _, failedSubTaskId := range retrievedTasks.FailedSubTaskIds {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+				fmt.Sprintf("Failed to retrieve tasks of Mavenlink sub-task %s", failedSubTaskId))
+			issuesAndTasks.AddTaskFailure(failedSubTaskId)
+		}
+	} else if changedErr := syncOps.retrieveChangedIssuesAndTasks(externalProject, jiraProject,
+		sprintsAndTasks.GetSubTasks(), cursors, issuesAndTasks); changedErr != nil {
+
+		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.CircularBulletPoint+utility.CircularBulletPoint,
+			fmt.Sprintf("Failed to retrieve the changed Mavenlink tasks: %v !!", changedErr))
+		success <- false
+		return
+	}
 
 	utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint, "Prepared object with")
 	utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.Check, fmt.Sprintf("Project - %s",
@@ -602,6 +2139,8 @@ func (syncOps *SyncOperations) SyncMavenlinkToJira(externalProject *datasourceCo
 
 	timeentriesCount := 0
 	worklogsCount := 0
+	postsCount := 0
+	attachmentsCount := 0
 	for _, issueInSprint := range issuesAndTasks.GetIssues() {
 		go syncOps.jira.GetWorklogsFromIssue(issueInSprint.Key, issuesInSprintsWorklogs)
 		worklogsCount++
@@ -610,6 +2149,12 @@ func (syncOps *SyncOperations) SyncMavenlinkToJira(externalProject *datasourceCo
 		go syncOps.mavenlink.GetTimeEntriesForIssueTask(externalProject.Source2ProjectId, taskInSubTask.Id,
 			tasksInSubTasksTimeentries)
 		timeentriesCount++
+		go syncOps.mavenlink.GetPostsForIssueTask(externalProject.Source2ProjectId, taskInSubTask.Id,
+			tasksInSubTasksPosts)
+		postsCount++
+		go syncOps.mavenlink.GetAttachmentsForIssueTask(externalProject.Source2ProjectId, taskInSubTask.Id,
+			tasksInSubTasksAttachments)
+		attachmentsCount++
 	}
 	for i := 0; i < worklogsCount; i++ {
 		for _, worklog := range <-issuesInSprintsWorklogs {
@@ -617,19 +2162,255 @@ func (syncOps *SyncOperations) SyncMavenlinkToJira(externalProject *datasourceCo
 		}
 	}
 	for i := 0; i < timeentriesCount; i++ {
-		for _, timeEntry := range <-tasksInSubTasksTimeentries {
+		taskTimeentries := <-tasksInSubTasksTimeentries
+		if taskTimeentries.Err != nil {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+				fmt.Sprintf("Failed to retrieve time entries of Mavenlink task %s: %v", taskTimeentries.TaskId,
+					taskTimeentries.Err))
+			issuesAndTasks.AddTimeentryFailure(taskTimeentries.TaskId)
+			continue
+		}
+		for _, timeEntry := range taskTimeentries.Timeentries {
 			issuesAndTasks.AddTimeentry(timeEntry)
 		}
 	}
+	for i := 0; i < postsCount; i++ {
+		for _, post := range <-tasksInSubTasksPosts {
+			issuesAndTasks.AddPost(post)
+		}
+	}
+	for i := 0; i < attachmentsCount; i++ {
+		for _, attachment := range <-tasksInSubTasksAttachments {
+			issuesAndTasks.AddAttachment(attachment)
+		}
+	}
+	for _, unmatchedUser := range syncOps.identity.GetUnmatchedMavenlinkUsers(issuesAndTasks) {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+			fmt.Sprintf("No JIRA user matches Mavenlink user '%s'(ID: %s, %s)", unmatchedUser.FullName,
+				unmatchedUser.Id, unmatchedUser.EmailAddress))
+	}
 	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.SeparationBlock, "")
-	completedSprintSync := syncOps.syncTasksAndSprints(externalProject.Id, sprintsAndTasks)
-	<-completedSprintSync
+	if externalProject.EpicMode {
+		syncOps.reportPhase(externalProject.Id, RunPhaseEpics)
+		completedEpicSync := syncOps.syncTasksAndEpics(externalProject.Id, jiraProject, sprintsAndTasks)
+		<-completedEpicSync
+	} else {
+		syncOps.reportPhase(externalProject.Id, RunPhaseSprints)
+		completedSprintSync := syncOps.syncTasksAndSprints(externalProject.Id, sprintsAndTasks)
+		<-completedSprintSync
+	}
 	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.SeparationBlock, "")
+	if syncOps.isJiraMaster() {
+		completedTaskSync := syncOps.syncIssuesAndTasks(issuesAndTasks)
+		<-completedTaskSync
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.SeparationBlock, "")
+	}
+	syncOps.reportPhase(externalProject.Id, RunPhaseIssues)
 	completedIssueSync := syncOps.syncTasksAndIssues(externalProject.Id, issuesAndTasks)
 	<-completedIssueSync
 	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.SeparationBlock, "")
+	// Only full runs know every task, so only they can tell which tasks were deleted
+	if fullRun {
+		completedDeletedTaskSync := syncOps.syncDeletedTasksAndIssues(externalProject, issuesAndTasks)
+		<-completedDeletedTaskSync
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.SeparationBlock, "")
+	}
+	completedCommentSync := syncOps.syncPostsAndComments(issuesAndTasks)
+	<-completedCommentSync
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.SeparationBlock, "")
+	completedAttachmentSync := syncOps.syncAttachments(issuesAndTasks)
+	<-completedAttachmentSync
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.SeparationBlock, "")
+	syncOps.reportPhase(externalProject.Id, RunPhaseWorklogs)
 	completedIssueWorklogSync := syncOps.syncWorklogsAndTimeEntries(externalProject.Source1ProjectId, issuesAndTasks)
 	<-completedIssueWorklogSync
 	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.SeparationBlock, "")
+	completedRemovedWorklogSync := syncOps.syncRemovedAndMovedTimeEntries(jiraProject, issuesAndTasks)
+	<-completedRemovedWorklogSync
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.SeparationBlock, "")
+	completedTimeentrySync := syncOps.syncTimeEntriesFromWorklogs(externalProject.Source2ProjectId, issuesAndTasks)
+	<-completedTimeentrySync
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.SeparationBlock, "")
+	syncOps.saveSyncCursors(externalProject.Id, startedAt, fullRun)
+	success <- true
+}
+
+// Sync a single Mavenlink task to its JIRA issue along with its time entries & the tasks they were moved from,
+// leaving the rest of the project to the full runs
+func (syncOps *SyncOperations) SyncMavenlinkTaskToJira(externalProject *datasourceCommunicator.ExternalProject,
+	taskId string, success chan bool) {
+
+	timeentries := make(chan POGO.TaskTimeentries)
+	worklogs := make(chan []jiraCommunicator.Worklog)
+	users := make(chan []jiraCommunicator.Author)
+	mavenlinkUsers := make(chan []mavenlinkCommunicator.User)
+	issuesAndTasks := &POGO.IssueAndTask{}
+
+	jiraProject := syncOps.jira.GetJiraProject(externalProject.Source1ProjectId)
+	if jiraProject == nil {
+		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.CircularBulletPoint+utility.CircularBulletPoint,
+			fmt.Sprintf("Failed to find JIRA project '%d'!!", externalProject.Source1ProjectId))
+		success <- false
+		return
+	}
+	var jiraEpic *jiraCommunicator.Issue
+	if !externalProject.EpicMode {
+		jiraEpic = syncOps.jira.GetEpicInJiraProject(
+			externalProject.ProjectKey + "-" + fmt.Sprint(externalProject.EpicId))
+		if jiraEpic == nil {
+			utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.CircularBulletPoint+utility.CircularBulletPoint,
+				fmt.Sprintf("Failed to find JIRA epic '%s'!!",
+					externalProject.ProjectKey+"-"+fmt.Sprint(externalProject.EpicId)))
+			success <- false
+			return
+		}
+	}
+	identities, identitiesErr := syncOps.getIdentityMapping(externalProject)
+	if identitiesErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.CircularBulletPoint+utility.CircularBulletPoint,
+			fmt.Sprintf("Failed to retrieve the identity mapping, leaving the project to the next run: %v !!",
+				identitiesErr))
+		success <- false
+		return
+	}
+	syncOps.reportPhase(externalProject.Id, RunPhaseBootstrapping)
+	task := syncOps.mavenlink.GetTaskInMavenlink(externalProject.Source2ProjectId, taskId)
+	if task == nil {
+		utility.GetUtilitiesSingleton().Logger.LevelTwoLog(utility.CircularBulletPoint+utility.CircularBulletPoint,
+			fmt.Sprintf("Failed to find Mavenlink task '%s'!!", taskId))
+		success <- false
+		return
+	}
+	// Only tasks in the synced sub-tasks of the configured milestones are synced
+	var jiraParentId string
+	if externalProject.EpicMode {
+		jiraParentId = syncOps.datasource.GetJiraEpicIdFromMavenlinkTaskId(
+			syncOps.common.GetIdFromString(task.ParentId))
+	} else {
+		jiraParentId = syncOps.datasource.GetJiraSprintIdFromMavenlinkTaskId(
+			syncOps.common.GetIdFromString(task.ParentId))
+	}
+	if len(jiraParentId) == 0 || jiraParentId == "0" {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+			fmt.Sprintf("Sub-task %s of Mavenlink task '%s' isn't synced, leaving it to the next full run",
+				task.ParentId, task.Title))
+		success <- true
+		return
+	}
+	// Time entries moved to the task are only detected along with the issues their worklogs are in, so the tasks
+	// they were synced from are synced too
+	go syncOps.mavenlink.GetTimeEntriesForIssueTask(externalProject.Source2ProjectId, task.Id, timeentries)
+	tasksTimeentries := []POGO.TaskTimeentries{<-timeentries}
+	tasks := []mavenlinkCommunicator.Task{*task}
+	collected := map[string]bool{task.Id: true}
+	timeentriesCount := 0
+	for _, timeEntry := range tasksTimeentries[0].Timeentries {
+		previousTaskId := syncOps.getSyncedTaskIdOfTimeEntry(timeEntry.Id)
+		if len(previousTaskId) == 0 || collected[previousTaskId] {
+			continue
+		}
+		previousTask := syncOps.mavenlink.GetTaskInMavenlink(externalProject.Source2ProjectId, previousTaskId)
+		if previousTask == nil {
+			utility.GetUtilitiesSingleton().Logger.LevelTwoLog(
+				utility.CircularBulletPoint+utility.CircularBulletPoint,
+				fmt.Sprintf("Failed to find Mavenlink task '%s' that time entry %s was synced from!!",
+					previousTaskId, timeEntry.Id))
+			for i := 0; i < timeentriesCount; i++ {
+				<-timeentries
+			}
+			success <- false
+			return
+		}
+		collected[previousTask.Id] = true
+		tasks = append(tasks, *previousTask)
+		go syncOps.mavenlink.GetTimeEntriesForIssueTask(externalProject.Source2ProjectId, previousTask.Id,
+			timeentries)
+		timeentriesCount++
+	}
+	var issues []jiraCommunicator.Issue
+	worklogsCount := 0
+	for _, syncableTask := range tasks {
+		syncedTask := syncOps.datasource.GetSyncedTaskFromMavenlinkTaskId(
+			syncOps.common.GetIdFromString(syncableTask.Id))
+		if syncedTask == nil {
+			continue
+		}
+		issue := syncOps.jira.RetrieveIssueInProject(jiraProject.Key, fmt.Sprint(syncedTask.Source1TaskId))
+		if issue == nil {
+			// Without its issue, the synced task would be taken for a new one & duplicated
+			utility.GetUtilitiesSingleton().Logger.LevelTwoLog(
+				utility.CircularBulletPoint+utility.CircularBulletPoint,
+				fmt.Sprintf("Failed to retrieve JIRA issue '%d' of Mavenlink task '%s'!!",
+					syncedTask.Source1TaskId, syncableTask.Id))
+			for i := 0; i < timeentriesCount; i++ {
+				<-timeentries
+			}
+			for i := 0; i < worklogsCount; i++ {
+				<-worklogs
+			}
+			success <- false
+			return
+		}
+		issues = append(issues, *issue)
+		go syncOps.jira.GetWorklogsFromIssue(issue.Key, worklogs)
+		worklogsCount++
+	}
+	for i := 0; i < timeentriesCount; i++ {
+		tasksTimeentries = append(tasksTimeentries, <-timeentries)
+	}
+	go syncOps.jira.GetUsersInProject(jiraProject.Key, users)
+	go syncOps.mavenlink.GetUsersInWorkspace(externalProject.Source2ProjectId, mavenlinkUsers)
+
+	syncOps.loadProjectEquivalence(externalProject.Id)
+	issuesAndTasks.SetExternalProjectId(externalProject.Id)
+	issuesAndTasks.SetIdentityMapping(identities)
+	issuesAndTasks.SetTimezone(externalProject.Timezone)
+	issuesAndTasks.SetEpicMode(externalProject.EpicMode)
+	issuesAndTasks.SetProject(jiraProject)
+	issuesAndTasks.SetEpic(jiraEpic)
+	issuesAndTasks.SetUsers(<-users)
+	issuesAndTasks.SetMavenlinkUsers(<-mavenlinkUsers)
+	issuesAndTasks.SetIssues(issues)
+	issuesAndTasks.SetTasks(tasks)
+	for _, taskTimeentries := range tasksTimeentries {
+		if taskTimeentries.Err != nil {
+			utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+				fmt.Sprintf("Failed to retrieve time entries of Mavenlink task %s: %v", taskTimeentries.TaskId,
+					taskTimeentries.Err))
+			issuesAndTasks.AddTimeentryFailure(taskTimeentries.TaskId)
+			continue
+		}
+		for _, timeEntry := range taskTimeentries.Timeentries {
+			issuesAndTasks.AddTimeentry(timeEntry)
+		}
+	}
+	for i := 0; i < worklogsCount; i++ {
+		for _, worklog := range <-worklogs {
+			issuesAndTasks.AddWorklog(worklog)
+		}
+	}
+	if len(tasks) > 1 {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint,
+			fmt.Sprintf("Syncing x%d Mavenlink tasks that time entries of '%s' were moved from", len(tasks)-1,
+				task.Title))
+	}
+	utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.TriangularBulletPoint,
+		fmt.Sprintf("Syncing Mavenlink task '%s' with x%d time entries & x%d worklogs", task.Title,
+			len(issuesAndTasks.GetTimeentries()), len(issuesAndTasks.GetWorklogs())))
+
+	if syncOps.isJiraMaster() {
+		completedTaskSync := syncOps.syncIssuesAndTasks(issuesAndTasks)
+		<-completedTaskSync
+	}
+	syncOps.reportPhase(externalProject.Id, RunPhaseIssues)
+	completedIssueSync := syncOps.syncTasksAndIssues(externalProject.Id, issuesAndTasks)
+	<-completedIssueSync
+	syncOps.reportPhase(externalProject.Id, RunPhaseWorklogs)
+	completedIssueWorklogSync := syncOps.syncWorklogsAndTimeEntries(externalProject.Source1ProjectId, issuesAndTasks)
+	<-completedIssueWorklogSync
+	completedRemovedWorklogSync := syncOps.syncRemovedAndMovedTimeEntries(jiraProject, issuesAndTasks)
+	<-completedRemovedWorklogSync
+	completedTimeentrySync := syncOps.syncTimeEntriesFromWorklogs(externalProject.Source2ProjectId, issuesAndTasks)
+	<-completedTimeentrySync
 	success <- true
 }
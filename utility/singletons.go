@@ -5,11 +5,14 @@
 package utility
 
 import (
+	"fmt"
 	jiraCommunicator "github.com/desertjinn/jira-communicator/proto/jira-communicator"
 	mavenlinkCommunicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
 	mavenlinkJiraDatasource "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
 	microclient "github.com/micro/go-micro/client"
+	"github.com/pkg/errors"
 	"golang.org/x/net/context"
+	"strings"
 	"sync"
 	"time"
 )
@@ -18,6 +21,74 @@ const (
 	MavenlinkService  = "costrategix.service.mavenlink.communicator"
 	JiraService       = "costrategix.service.jira.communicator"
 	DatasourceService = "costrategix.service.mavenlink.jira.datasource"
+	SyncService       = "costrategix.service.mavenlink.jira.sync"
+)
+
+const (
+	MasterMavenlink = "mavenlink"
+	MasterJira      = "jira"
+)
+
+const (
+	DeletionPolicyIgnore = "ignore"
+	DeletionPolicyClose  = "close"
+	DeletionPolicyLabel  = "label"
+	DeletedTaskLabel     = "mavenlink-deleted"
+)
+
+// Label recording the sync configuration an epic was provisioned for, followed by the configuration's ID
+const ProvisionedEpicLabelPrefix = "mavenlink-sync-"
+
+const (
+	JiraSprintFieldName   = "Sprint"
+	JiraEpicLinkFieldName = "Epic Link"
+	JiraEpicNameFieldName = "Epic Name"
+	JiraEpicIssueTypeName = "Epic"
+)
+
+// Time a run is given to finish, its remaining calls failing once it's up
+const RunTimeout = 300 * time.Second
+
+// Let JIRA reduce an issue's remaining estimate by the time logged in a synced worklog
+const AdjustEstimateAuto = "auto"
+
+// Transitions applied while moving an issue through its workflow before giving up
+const MaxTransitionSteps = 20
+
+const (
+	JiraDateTimeFormat  = "2006-01-02T15:04:05.000-0700"
+	MavenlinkDateFormat = "2006-01-02"
+	// Local hour of the day at which synced worklogs start
+	WorklogStartHour = 6
+)
+
+// Timezone of the team the synchronizer was first built for, used when no other timezone can be worked out
+var DefaultWorklogLocation = time.FixedZone("IST", (5*60+30)*60)
+
+const (
+	DefaultMilestone  = "Construction"
+	MaxAttachmentSize = 10 * 1024 * 1024
+	// Size of the chunks attachments are uploaded to JIRA in
+	AttachmentChunkSize = 64 * 1024
+	// Attachments synced at the same time, each being spooled to disk on the way
+	MaxConcurrentAttachments = 4
+)
+
+// Entities whose high-water marks are kept per project, the last full run being marked too
+const (
+	SyncCursorTasks       = "tasks"
+	SyncCursorTimeEntries = "timeentries"
+	SyncCursorFullRun     = "full"
+	// Time between the full runs of a project, the runs in between only syncing what changed
+	DefaultFullSyncInterval = 6 * time.Hour
+	// Overlap of consecutive runs' windows, covering clock skew with Mavenlink & changes made during a run
+	SyncCursorOverlap = time.Minute
+)
+
+const (
+	SprintStateFuture = "future"
+	SprintStateActive = "active"
+	SprintStateClosed = "closed"
 )
 
 const (
@@ -47,6 +118,16 @@ var once sync.Once
 var utilities *Utilities
 var logging *FormatLog
 
+// Equivalence relations loaded from the datasource, keyed by the external project ID
+var projectEquivalences = map[int32]map[string]map[string][]string{}
+var projectEquivalencesLock sync.RWMutex
+
+// Guards JIRA's issue types, statuses, priorities & custom field IDs, which the long-running modes refresh
+var jiraMetadataLock sync.RWMutex
+
+// Failure to retrieve JIRA's metadata when the singleton was initialised, left to the modes to act on
+var jiraMetadataErr error
+
 // A struct of reusable single instance functionality
 // provided using a Singleton pattern
 type Utilities struct {
@@ -61,6 +142,9 @@ type Utilities struct {
 	JiraIssueTypes             []*jiraCommunicator.IssueType
 	JiraStatuses               []*jiraCommunicator.Status
 	JiraPriorities             []*jiraCommunicator.Priority
+	JiraSprintFieldId          string
+	JiraEpicLinkFieldId        string
+	JiraEpicNameFieldId        string
 }
 
 // Retrieve the Utilities singleton struct
@@ -71,9 +155,12 @@ func GetUtilitiesSingleton() *Utilities {
 		temp := initialiseUtilities()
 		logging.LevelOneLog(Check, "Initialised Level 1")
 		logging.LevelOneLog(CircularBulletPoint, "Triggering Level 2 singletons...")
-		temp = initialiseAdditionalUtilities(temp)
 		utilities = &temp
-		logging.LevelOneLog(Check, "Initialised Level 2")
+		if jiraMetadataErr = initialiseAdditionalUtilities(utilities); jiraMetadataErr != nil {
+			logging.LevelOneLog(Cross, jiraMetadataErr.Error())
+		} else {
+			logging.LevelOneLog(Check, "Initialised Level 2")
+		}
 		logging.LevelZeroLog(SeparationBlock, "")
 	})
 	return utilities
@@ -93,19 +180,94 @@ func initialiseUtilities() Utilities {
 	}
 }
 
-// Initialise the 2nd level of the Utilities singleton
-func initialiseAdditionalUtilities(utilities Utilities) Utilities {
-	utilities.JiraIssueTypes = getJiraIssueTypeMetadata(utilities.JiraClient, utilities.CommsContext)
-	utilities.JiraStatuses = getJiraStatusMetadata(utilities.JiraClient, utilities.CommsContext)
-	utilities.JiraPriorities = getJiraPriorityMetadata(utilities.JiraClient, utilities.CommsContext)
-
-	return utilities
+// Initialise the 2nd level of the Utilities singleton, failing when JIRA's metadata can't be retrieved
+func initialiseAdditionalUtilities(utilities *Utilities) error {
+	return utilities.RefreshJiraMetadata(utilities.CommsContext)
 }
 
 // Retrieve the context used in various calls
 func getContext() (context.Context, context.CancelFunc) {
 	//return context.WithCancel(context.Background())
-	return context.WithTimeout(context.Background(), time.Second*300)
+	return context.WithTimeout(context.Background(), RunTimeout)
+}
+
+// Retrieve JIRA's issue types, statuses, priorities & custom field IDs again, keeping the ones retrieved before when
+// JIRA returns none or the custom fields can't be resolved
+func (utilities *Utilities) RefreshJiraMetadata(commsContext context.Context) error {
+	issueTypes := getJiraIssueTypeMetadata(utilities.JiraClient, commsContext)
+	statuses := getJiraStatusMetadata(utilities.JiraClient, commsContext)
+	priorities := getJiraPriorityMetadata(utilities.JiraClient, commsContext)
+	sprintFieldId, epicLinkFieldId, epicNameFieldId, fieldsErr :=
+		getJiraCustomFieldMetadata(utilities.JiraClient, commsContext)
+
+	jiraMetadataLock.Lock()
+	defer jiraMetadataLock.Unlock()
+	if len(issueTypes) > 0 {
+		utilities.JiraIssueTypes = issueTypes
+	}
+	if len(statuses) > 0 {
+		utilities.JiraStatuses = statuses
+	}
+	if len(priorities) > 0 {
+		utilities.JiraPriorities = priorities
+	}
+	if fieldsErr != nil {
+		return fieldsErr
+	}
+	utilities.JiraSprintFieldId = sprintFieldId
+	utilities.JiraEpicLinkFieldId = epicLinkFieldId
+	utilities.JiraEpicNameFieldId = epicNameFieldId
+	jiraMetadataErr = nil
+	return nil
+}
+
+// Retrieve the failure to retrieve JIRA's metadata, nil once it has been retrieved
+func (utilities *Utilities) GetJiraMetadataError() error {
+	jiraMetadataLock.RLock()
+	defer jiraMetadataLock.RUnlock()
+	return jiraMetadataErr
+}
+
+// Retrieve the ID of JIRA's "Sprint" custom field
+func (utilities *Utilities) GetJiraSprintFieldId() string {
+	jiraMetadataLock.RLock()
+	defer jiraMetadataLock.RUnlock()
+	return utilities.JiraSprintFieldId
+}
+
+// Retrieve the ID of JIRA's "Epic Link" custom field
+func (utilities *Utilities) GetJiraEpicLinkFieldId() string {
+	jiraMetadataLock.RLock()
+	defer jiraMetadataLock.RUnlock()
+	return utilities.JiraEpicLinkFieldId
+}
+
+// Retrieve the ID of JIRA's "Epic Name" custom field, empty when JIRA has none
+func (utilities *Utilities) GetJiraEpicNameFieldId() string {
+	jiraMetadataLock.RLock()
+	defer jiraMetadataLock.RUnlock()
+	return utilities.JiraEpicNameFieldId
+}
+
+// Retrieve JIRA's issue types
+func (utilities *Utilities) GetJiraIssueTypes() []*jiraCommunicator.IssueType {
+	jiraMetadataLock.RLock()
+	defer jiraMetadataLock.RUnlock()
+	return utilities.JiraIssueTypes
+}
+
+// Retrieve JIRA's statuses
+func (utilities *Utilities) GetJiraStatuses() []*jiraCommunicator.Status {
+	jiraMetadataLock.RLock()
+	defer jiraMetadataLock.RUnlock()
+	return utilities.JiraStatuses
+}
+
+// Retrieve JIRA's priorities
+func (utilities *Utilities) GetJiraPriorities() []*jiraCommunicator.Priority {
+	jiraMetadataLock.RLock()
+	defer jiraMetadataLock.RUnlock()
+	return utilities.JiraPriorities
 }
 
 // Retrieve a communicator instance of the datasource for sync configurations
@@ -134,11 +296,11 @@ func getLogger() FormatLogInterface {
 // Retrieve the issue types equivalence relation between Mavenlink & JIRA(Mavenlink -> JIRA)
 func GetMavenlinkToJiraIssueTypesEquivalence() (issueRelations map[string][]string) {
 	issueRelations = make(map[string][]string)
-	jiraTaskType := []string{"new feature", "task", "improvement", "provisioining", "sub-task", "performance",
+	jiraTaskType := []string{"task", "new feature", "improvement", "provisioining", "sub-task", "performance",
 		"support", "epic", "story", "technical task", "fulfillment", "seo", "promotion", "test"}
 	issueRelations["task"] = jiraTaskType
 
-	jiraIssueType := []string{"development bug", "bug", "defect"}
+	jiraIssueType := []string{"bug", "development bug", "defect"}
 	issueRelations["issue"] = jiraIssueType
 
 	return issueRelations
@@ -174,6 +336,32 @@ func GetMavenlinkToJiraStatusesEquivalence() (statusRelations map[string][]strin
 	return statusRelations
 }
 
+// Retrieve the status equivalence relation between JIRA & Mavenlink(JIRA -> Mavenlink)
+func GetJiraToMavenlinkStatusesEquivalence() (statusRelations map[string]string) {
+	statusRelations = make(map[string]string)
+
+	statusRelations["open"] = "not started"
+
+	statusRelations["in progress"] = "started"
+	statusRelations["review"] = "started"
+
+	statusRelations["internal production validation"] = "fixed"
+	statusRelations["internal staging validation"] = "fixed"
+	statusRelations["internal qa"] = "fixed"
+	statusRelations["approved for prod"] = "fixed"
+	statusRelations["approved for stage"] = "fixed"
+
+	statusRelations["reopened"] = "reopened"
+
+	statusRelations["resolved"] = "resolved"
+
+	statusRelations["closed"] = "completed"
+
+	statusRelations["require feedback"] = "needs info"
+
+	return statusRelations
+}
+
 // Retrieve the status equivalence relation between Mavenlink & JIRA(Mavenlink -> JIRA)
 func GetMavenlinkToJiraPrioritiesEquivalence() (prioritiesRelations map[string][]string) {
 	prioritiesRelations = make(map[string][]string)
@@ -198,6 +386,47 @@ func getMavenlinkToJiraEquivalence() (relations map[string]map[string][]string)
 	return relations
 }
 
+// Override the given equivalence relations with another set, Mavenlink value by Mavenlink value
+func MergeMavenlinkToJiraEquivalence(relations map[string]map[string][]string,
+	overrides map[string]map[string][]string) map[string]map[string][]string {
+
+	merged := make(map[string]map[string][]string)
+	for equivalenceType, equivalence := range relations {
+		merged[equivalenceType] = make(map[string][]string)
+		for mavenlink, jira := range equivalence {
+			merged[equivalenceType][mavenlink] = jira
+		}
+	}
+	for equivalenceType, equivalence := range overrides {
+		if _, ok := merged[equivalenceType]; !ok {
+			merged[equivalenceType] = make(map[string][]string)
+		}
+		for mavenlink, jira := range equivalence {
+			merged[equivalenceType][mavenlink] = jira
+		}
+	}
+	return merged
+}
+
+// Store the equivalence relations to be used while syncing an external project
+func (utilities *Utilities) SetProjectEquivalence(externalProjectId int32,
+	relations map[string]map[string][]string) {
+
+	projectEquivalencesLock.Lock()
+	defer projectEquivalencesLock.Unlock()
+	projectEquivalences[externalProjectId] = relations
+}
+
+// Retrieve the equivalence relations of an external project, falling back to the global default
+func (utilities *Utilities) GetProjectEquivalence(externalProjectId int32) map[string]map[string][]string {
+	projectEquivalencesLock.RLock()
+	defer projectEquivalencesLock.RUnlock()
+	if relations, ok := projectEquivalences[externalProjectId]; ok {
+		return relations
+	}
+	return utilities.MavenlinkToJiraEquivalence
+}
+
 func getJiraStatusMetadata(jiraClient jiraCommunicator.JiraCommunicatorClient,
 	commsContext context.Context) (statuses []*jiraCommunicator.Status) {
 
@@ -236,3 +465,49 @@ func getJiraIssueTypeMetadata(jiraClient jiraCommunicator.JiraCommunicatorClient
 	}
 	return issueTypes
 }
+
+// Resolve the IDs of the "Sprint", "Epic Link" & "Epic Name" custom fields by name from JIRA's field list, failing
+// when the first two can't be resolved as nothing can be synced without them
+func getJiraCustomFieldMetadata(jiraClient jiraCommunicator.JiraCommunicatorClient,
+	commsContext context.Context) (sprintFieldId string, epicLinkFieldId string, epicNameFieldId string, err error) {
+
+	var request jiraCommunicator.Request
+	response, responseErr := jiraClient.GetFields(commsContext, &request)
+	if nil != responseErr {
+		return "", "", "", errors.New(fmt.Sprintf("Failed to retrieve JIRA's fields: %v", responseErr))
+	}
+	if nil != response.Error {
+		return "", "", "", errors.New(fmt.Sprintf("Failed to retrieve JIRA's fields: %v", response.Error))
+	}
+	for _, field := range response.Fields {
+		if !field.Custom {
+			continue
+		}
+		if len(sprintFieldId) == 0 && strings.EqualFold(field.Name, JiraSprintFieldName) {
+			sprintFieldId = field.Id
+		}
+		if len(epicLinkFieldId) == 0 && strings.EqualFold(field.Name, JiraEpicLinkFieldName) {
+			epicLinkFieldId = field.Id
+		}
+		if len(epicNameFieldId) == 0 && strings.EqualFold(field.Name, JiraEpicNameFieldName) {
+			epicNameFieldId = field.Id
+		}
+	}
+	var missingFields []string
+	if len(sprintFieldId) == 0 {
+		missingFields = append(missingFields, JiraSprintFieldName)
+	}
+	if len(epicLinkFieldId) == 0 {
+		missingFields = append(missingFields, JiraEpicLinkFieldName)
+	}
+	if len(missingFields) > 0 {
+		return "", "", "", errors.New(fmt.Sprintf(
+			"Failed to resolve JIRA's custom '%s' field(s), which syncing requires",
+			strings.Join(missingFields, "' & '")))
+	}
+	if len(epicNameFieldId) == 0 {
+		logging.LevelOneLog(Warning, fmt.Sprintf("Failed to resolve JIRA '%s' field, epics are created without it",
+			JiraEpicNameFieldName))
+	}
+	return sprintFieldId, epicLinkFieldId, epicNameFieldId, nil
+}
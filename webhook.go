@@ -0,0 +1,322 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	jiraCommunicator "github.com/desertjinn/jira-communicator/proto/jira-communicator"
+	datasourceCommunicator "github.com/desertjinn/mavenlink-jira-datasource/proto/mavenlink-jira-datasource"
+	"github.com/desertjinn/mavenlink-jira-sync/POGO"
+	"github.com/desertjinn/mavenlink-jira-sync/utility"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MavenlinkWebhookPath = "/webhooks/mavenlink"
+	JiraWebhookPath      = "/webhooks/jira"
+)
+
+const (
+	MavenlinkSubjectStory     = "Story"
+	MavenlinkSubjectTimeEntry = "TimeEntry"
+)
+
+const (
+	// Number of targets waiting to be synced before further webhooks are turned away
+	WebhookQueueSize = 1000
+	// Time before retrying a target whose project's run was still going
+	WebhookRetryDelay = 30 * time.Second
+	MaxWebhookSize    = 1024 * 1024
+)
+
+// Payload of a Mavenlink webhook
+type MavenlinkWebhookPayload struct {
+	EventType   string      `json:"event_type"`
+	SubjectType string      `json:"subject_type"`
+	SubjectId   json.Number `json:"subject_id"`
+	WorkspaceId json.Number `json:"workspace_id"`
+}
+
+// JIRA account that made the change a webhook reports
+type JiraWebhookUser struct {
+	AccountId    string `json:"accountId"`
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+// Payload of a JIRA webhook, only the changed issue or worklog & who changed it being read
+type JiraWebhookPayload struct {
+	WebhookEvent string           `json:"webhookEvent"`
+	User         *JiraWebhookUser `json:"user"`
+	Issue        *struct {
+		Id  string `json:"id"`
+		Key string `json:"key"`
+	} `json:"issue"`
+	Worklog *struct {
+		Id           string           `json:"id"`
+		IssueId      string           `json:"issueId"`
+		UpdateAuthor *JiraWebhookUser `json:"updateAuthor"`
+	} `json:"worklog"`
+}
+
+// Get the account that made the change, worklog events naming it in the worklog only
+func (payload *JiraWebhookPayload) getChangedBy() *JiraWebhookUser {
+	if payload.User != nil {
+		return payload.User
+	}
+	if payload.Worklog != nil {
+		return payload.Worklog.UpdateAuthor
+	}
+	return nil
+}
+
+// Mavenlink task to sync in a project
+type webhookTarget struct {
+	externalProjectId int32
+	taskId            string
+}
+
+func (target webhookTarget) key() string {
+	return fmt.Sprintf("%d/%s", target.externalProjectId, target.taskId)
+}
+
+// Receives Mavenlink & JIRA webhooks, queueing a sync of just the changed task through the serve subcommand's
+// scheduler so it never overlaps a run of its project
+type WebhookReceiver struct {
+	syncOps   *SyncOperations
+	scheduler *Scheduler
+	secret    string
+	lock      sync.Mutex
+	pending   map[string]bool
+	queue     chan webhookTarget
+}
+
+func NewWebhookReceiver(syncOps *SyncOperations, scheduler *Scheduler, secret string) *WebhookReceiver {
+	return &WebhookReceiver{
+		syncOps:   syncOps,
+		scheduler: scheduler,
+		secret:    secret,
+		pending:   map[string]bool{},
+		queue:     make(chan webhookTarget, WebhookQueueSize),
+	}
+}
+
+// Receive webhooks on the address, syncing their targets one at a time
+func (receiver *WebhookReceiver) Listen(address string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(MavenlinkWebhookPath, receiver.handleMavenlink)
+	mux.HandleFunc(JiraWebhookPath, receiver.handleJira)
+	go receiver.work()
+	if receiver.syncOps.environment == nil || len(receiver.syncOps.environment.JiraSyncAccount) == 0 {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+			"No jiraSyncAccount is configured, the sync's own JIRA changes queue their tasks again")
+	}
+	utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.TriangularBulletPoint,
+		fmt.Sprintf("Receiving webhooks on %s%s & %s%s", address, MavenlinkWebhookPath, address, JiraWebhookPath))
+	if listenErr := http.ListenAndServe(address, mux); listenErr != nil {
+		utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+			fmt.Sprintf("Failed to receive webhooks on %s: %v", address, listenErr))
+	}
+}
+
+// Read the payload of a webhook, responding to the webhook itself when it can't be accepted
+func (receiver *WebhookReceiver) readPayload(writer http.ResponseWriter, request *http.Request,
+	payload interface{}) bool {
+
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Only POST is supported", http.StatusMethodNotAllowed)
+		return false
+	}
+	if len(receiver.secret) == 0 || subtle.ConstantTimeCompare([]byte(receiver.secret),
+		[]byte(request.URL.Query().Get("secret"))) != 1 {
+
+		http.Error(writer, "Invalid secret", http.StatusUnauthorized)
+		return false
+	}
+	decodeErr := json.NewDecoder(http.MaxBytesReader(writer, request.Body, MaxWebhookSize)).Decode(payload)
+	if decodeErr != nil {
+		http.Error(writer, fmt.Sprintf("Invalid payload: %v", decodeErr), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// Queue the target of a webhook, or ignore the webhook when it has none
+func (receiver *WebhookReceiver) respond(writer http.ResponseWriter, target *webhookTarget, subject string) {
+	if target == nil {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.Warning,
+			fmt.Sprintf("Ignored the webhook of %s, it isn't synced", subject))
+		writer.WriteHeader(http.StatusOK)
+		return
+	}
+	if !receiver.enqueue(target) {
+		http.Error(writer, "Too many pending syncs", http.StatusServiceUnavailable)
+		return
+	}
+	utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.CircularBulletPoint,
+		fmt.Sprintf("Queued Mavenlink task %s of external project %d for %s", target.taskId,
+			target.externalProjectId, subject))
+	writer.WriteHeader(http.StatusAccepted)
+}
+
+func (receiver *WebhookReceiver) handleMavenlink(writer http.ResponseWriter, request *http.Request) {
+	payload := new(MavenlinkWebhookPayload)
+	if !receiver.readPayload(writer, request, payload) {
+		return
+	}
+	receiver.respond(writer, receiver.getMavenlinkTarget(payload),
+		fmt.Sprintf("Mavenlink %s %s(%s)", payload.SubjectType, payload.SubjectId, payload.EventType))
+}
+
+func (receiver *WebhookReceiver) handleJira(writer http.ResponseWriter, request *http.Request) {
+	payload := new(JiraWebhookPayload)
+	if !receiver.readPayload(writer, request, payload) {
+		return
+	}
+	var target *webhookTarget
+	var issueId string
+	if payload.Worklog != nil {
+		issueId = payload.Worklog.IssueId
+	} else if payload.Issue != nil {
+		issueId = payload.Issue.Id
+	}
+	// The sync's own edits, transitions & worklogs would otherwise queue the task again as soon as it's synced
+	if receiver.isSyncChange(payload) {
+		utility.GetUtilitiesSingleton().Logger.LevelOneLog(utility.CircularBulletPoint,
+			fmt.Sprintf("Ignored the webhook of JIRA issue %s(%s), the sync made the change", issueId,
+				payload.WebhookEvent))
+		writer.WriteHeader(http.StatusOK)
+		return
+	}
+	if issueId64, issueId64Err := strconv.ParseInt(issueId, 10, 32); issueId64Err == nil {
+		target = receiver.getJiraIssueTarget(int32(issueId64))
+	}
+	receiver.respond(writer, target, fmt.Sprintf("JIRA issue %s(%s)", issueId, payload.WebhookEvent))
+}
+
+// Check if a JIRA change was made by the account the sync acts as
+func (receiver *WebhookReceiver) isSyncChange(payload *JiraWebhookPayload) bool {
+	changedBy := payload.getChangedBy()
+	if changedBy == nil || receiver.syncOps.environment == nil {
+		return false
+	}
+	identities := new(POGO.IdentityMapping)
+	identities.SetSyncAccount(receiver.syncOps.environment.JiraSyncAccount)
+	return identities.IsSyncAuthor(&jiraCommunicator.Author{AccountId: changedBy.AccountId, Name: changedBy.Name,
+		EmailAddress: changedBy.EmailAddress})
+}
+
+// Get the target of a Mavenlink webhook, deletions being left to the next full run
+func (receiver *WebhookReceiver) getMavenlinkTarget(payload *MavenlinkWebhookPayload) *webhookTarget {
+	subjectId64, subjectId64Err := strconv.ParseInt(payload.SubjectId.String(), 10, 32)
+	if subjectId64Err != nil || strings.Contains(strings.ToLower(payload.EventType), "delete") {
+		return nil
+	}
+	switch payload.SubjectType {
+	case MavenlinkSubjectStory:
+		if syncedTask := receiver.syncOps.datasource.GetSyncedTaskFromMavenlinkTaskId(
+			int32(subjectId64)); syncedTask != nil {
+
+			return &webhookTarget{syncedTask.ExternalProjectId, fmt.Sprint(syncedTask.Source2TaskId)}
+		}
+		if syncConfiguration := receiver.getWorkspaceConfiguration(payload.WorkspaceId.String()); syncConfiguration != nil {
+			return &webhookTarget{syncConfiguration.Id, payload.SubjectId.String()}
+		}
+	case MavenlinkSubjectTimeEntry:
+		// The time entry's current task is synced, which brings along the task it was synced from when it moved
+		if syncConfiguration := receiver.getWorkspaceConfiguration(payload.WorkspaceId.String()); syncConfiguration != nil {
+			timeentry := receiver.syncOps.mavenlink.GetTimeentryInMavenlink(syncConfiguration.Source2ProjectId,
+				payload.SubjectId.String())
+			if timeentry != nil && len(timeentry.StoryId) > 0 {
+				return &webhookTarget{syncConfiguration.Id, timeentry.StoryId}
+			}
+		}
+		if syncedTimeEntry := receiver.syncOps.datasource.GetSyncedTimeEntryFromMavenlinkTimeEntryId(
+			int32(subjectId64)); syncedTimeEntry != nil {
+
+			return receiver.getJiraIssueTarget(syncedTimeEntry.Source1TaskId)
+		}
+	}
+	return nil
+}
+
+// Get the synced Mavenlink task of a JIRA issue
+func (receiver *WebhookReceiver) getJiraIssueTarget(issueId int32) *webhookTarget {
+	syncedTask := receiver.syncOps.datasource.GetSyncedTaskFromJiraIssueId(issueId)
+	if syncedTask == nil {
+		return nil
+	}
+	return &webhookTarget{syncedTask.ExternalProjectId, fmt.Sprint(syncedTask.Source2TaskId)}
+}
+
+// Get the sync configuration of a Mavenlink workspace
+func (receiver *WebhookReceiver) getWorkspaceConfiguration(
+	workspaceId string) *datasourceCommunicator.ExternalProject {
+
+	syncConfigurations, err := receiver.syncOps.datasource.GetSyncConfiguration()
+	if err != nil {
+		return nil
+	}
+	for _, syncConfiguration := range syncConfigurations {
+		if syncConfiguration.DeleteFlag == 0 && fmt.Sprint(syncConfiguration.Source2ProjectId) == workspaceId {
+			return syncConfiguration
+		}
+	}
+	return nil
+}
+
+// Queue a target unless it's already waiting, failing only when the queue is full
+func (receiver *WebhookReceiver) enqueue(target *webhookTarget) bool {
+	receiver.lock.Lock()
+	defer receiver.lock.Unlock()
+	if receiver.pending[target.key()] {
+		return true
+	}
+	select {
+	case receiver.queue <- *target:
+		receiver.pending[target.key()] = true
+		return true
+	default:
+		return false
+	}
+}
+
+// Sync the queued targets, retrying the ones whose project's run is still going
+func (receiver *WebhookReceiver) work() {
+	for target := range receiver.queue {
+		// A change received while the target syncs queues it again
+		receiver.lock.Lock()
+		delete(receiver.pending, target.key())
+		receiver.lock.Unlock()
+
+		syncConfiguration := receiver.scheduler.getSyncConfiguration(target.externalProjectId)
+		if syncConfiguration == nil {
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+				fmt.Sprintf("No sync configuration found for external project(ID: %d)", target.externalProjectId))
+			continue
+		}
+		synced, runErr := receiver.scheduler.RunTask(syncConfiguration, target.taskId)
+		if runErr != nil {
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Warning,
+				fmt.Sprintf("Retrying Mavenlink task %s of '%s' in %v: %v", target.taskId,
+					syncConfiguration.ProjectName, WebhookRetryDelay, runErr))
+			retried := target
+			time.AfterFunc(WebhookRetryDelay, func() {
+				if !receiver.enqueue(&retried) {
+					utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+						fmt.Sprintf("Dropped Mavenlink task %s of '%s', too many pending syncs", retried.taskId,
+							syncConfiguration.ProjectName))
+				}
+			})
+			continue
+		}
+		if !synced {
+			utility.GetUtilitiesSingleton().Logger.LevelZeroLog(utility.Cross,
+				fmt.Sprintf("Failed to sync Mavenlink task %s of '%s'", target.taskId, syncConfiguration.ProjectName))
+		}
+	}
+}